@@ -0,0 +1,232 @@
+package crdbtypes
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// Date is a DATE value, a day of the calendar without time or time zone, so
+// that it doesn't shift when it's read in another time zone.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the date of t, in the location of t.
+func DateOf(t time.Time) Date {
+	var d Date
+	d.Year, d.Month, d.Day = t.Date()
+	return d
+}
+
+// ParseDate parses a date in the YYYY-MM-DD format.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return Date{}, errors.Wrapf(err, "crdbtypes: invalid date %q", s)
+	}
+	return DateOf(t), nil
+}
+
+// String returns the date in the YYYY-MM-DD format.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// IsValid tells whether d is a day of the calendar, the zero Date isn't.
+func (d Date) IsValid() bool {
+	return DateOf(d.In(time.UTC)) == d
+}
+
+// In returns the start of the day of d in loc.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// AddDays returns the date n days after d.
+func (d Date) AddDays(n int) Date {
+	return DateOf(d.In(time.UTC).AddDate(0, 0, n))
+}
+
+// Before tells whether d is before u.
+func (d Date) Before(u Date) bool {
+	return d.In(time.UTC).Before(u.In(time.UTC))
+}
+
+// After tells whether d is after u.
+func (d Date) After(u Date) bool {
+	return u.Before(d)
+}
+
+// Scan implements the sql.Scanner interface. Drivers like lib/pq read DATE
+// values as a time.Time at midnight UTC.
+func (d *Date) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case time.Time:
+		*d = DateOf(src)
+		return nil
+	case string:
+		return d.UnmarshalText([]byte(src))
+	case []byte:
+		return d.UnmarshalText(src)
+	}
+	return errors.Errorf("crdbtypes: cannot scan %T into Date", src)
+}
+
+// Value implements the driver.Valuer interface.
+func (d Date) Value() (driver.Value, error) {
+	if !d.IsValid() {
+		return nil, errors.Errorf("crdbtypes: invalid Date %s", d)
+	}
+	return d.String(), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Date) UnmarshalText(text []byte) error {
+	v, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// Randomize implements randomize.Randomizer.
+func (d *Date) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*d = Date{}
+		return
+	}
+	*d = randomDate(nextInt)
+}
+
+func randomDate(nextInt func() int64) Date {
+	return Date{
+		Year:  2000 + int(nextInt()%50),
+		Month: time.Month(1 + nextInt()%12),
+		Day:   1 + int(nextInt()%28),
+	}
+}
+
+// NullDate is a Date that may be NULL.
+type NullDate struct {
+	Date  Date
+	Valid bool
+}
+
+// NewNullDate creates a new NullDate.
+func NewNullDate(d Date, valid bool) NullDate {
+	return NullDate{Date: d, Valid: valid}
+}
+
+// NullDateFrom creates a new NullDate that is never NULL.
+func NullDateFrom(d Date) NullDate {
+	return NewNullDate(d, true)
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *NullDate) Scan(src interface{}) error {
+	if src == nil {
+		*n = NullDate{}
+		return nil
+	}
+
+	n.Valid = true
+	return n.Date.Scan(src)
+}
+
+// Value implements the driver.Valuer interface.
+func (n NullDate) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Date.Value()
+}
+
+// MarshalJSON implements json.Marshaler, a NULL date is null.
+func (n NullDate) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.Date)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullDate) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = NullDate{}
+		return nil
+	}
+
+	n.Valid = true
+	return json.Unmarshal(data, &n.Date)
+}
+
+// Randomize implements randomize.Randomizer.
+func (n *NullDate) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*n = NullDate{}
+		return
+	}
+	*n = NullDateFrom(randomDate(nextInt))
+}
+
+// DateArray is a DATE[] value, a nil slice is NULL.
+type DateArray []Date
+
+// Scan implements the sql.Scanner interface.
+func (a *DateArray) Scan(src interface{}) error {
+	var strs pq.StringArray
+	if err := strs.Scan(src); err != nil {
+		return errors.Wrap(err, "crdbtypes: cannot scan DateArray")
+	}
+	if strs == nil {
+		*a = nil
+		return nil
+	}
+
+	dates := make(DateArray, len(strs))
+	for i, s := range strs {
+		if err := dates[i].UnmarshalText([]byte(s)); err != nil {
+			return err
+		}
+	}
+	*a = dates
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (a DateArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+
+	strs := make(pq.StringArray, len(a))
+	for i, d := range a {
+		if !d.IsValid() {
+			return nil, errors.Errorf("crdbtypes: invalid Date %s in DateArray", d)
+		}
+		strs[i] = d.String()
+	}
+	return strs.Value()
+}
+
+// Randomize implements randomize.Randomizer.
+func (a *DateArray) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*a = nil
+		return
+	}
+	*a = DateArray{randomDate(nextInt)}
+}
@@ -0,0 +1,118 @@
+package crdbtypes
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	t.Parallel()
+
+	d, err := ParseDate("2021-02-28")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d != (Date{2021, time.February, 28}) || d.String() != "2021-02-28" {
+		t.Errorf("want 2021-02-28, got %+v", d)
+	}
+	if d.AddDays(1) != (Date{2021, time.March, 1}) || !d.Before(d.AddDays(1)) || !d.After(d.AddDays(-1)) {
+		t.Errorf("want 2021-03-01 the day after, got %v", d.AddDays(1))
+	}
+
+	for _, in := range []string{"", "2021-02-29", "2021-2-28", "28/02/2021"} {
+		if _, err := ParseDate(in); err == nil {
+			t.Errorf("%q: want an error", in)
+		}
+	}
+	if (Date{}).IsValid() || (Date{2021, time.February, 29}).IsValid() {
+		t.Error("want invalid dates")
+	}
+}
+
+func TestDateScanValue(t *testing.T) {
+	t.Parallel()
+
+	// lib/pq reads dates at midnight UTC, they mustn't shift in other locations
+	var d Date
+	if err := d.Scan(time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	if d != (Date{2021, time.March, 1}) {
+		t.Errorf("want 2021-03-01, got %v", d)
+	}
+	if v, err := d.Value(); err != nil || v != "2021-03-01" {
+		t.Errorf("want 2021-03-01, got %v %v", v, err)
+	}
+	if err := d.Scan([]byte("1999-12-31")); err != nil || d != (Date{1999, time.December, 31}) {
+		t.Errorf("want 1999-12-31, got %v %v", d, err)
+	}
+	if _, err := (Date{}).Value(); err == nil {
+		t.Error("want an error for the zero Date")
+	}
+
+	var n NullDate
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("want an invalid NullDate, got %+v %v", n, err)
+	}
+	if v, err := n.Value(); err != nil || v != nil {
+		t.Errorf("want nil, got %v %v", v, err)
+	}
+
+	var a DateArray
+	if err := a.Scan([]byte(`{2021-03-01,1999-12-31}`)); err != nil {
+		t.Fatal(err)
+	}
+	if len(a) != 2 || a[0] != (Date{2021, time.March, 1}) || a[1] != (Date{1999, time.December, 31}) {
+		t.Errorf("want [2021-03-01 1999-12-31], got %v", a)
+	}
+	if v, err := a.Value(); err != nil || v != `{"2021-03-01","1999-12-31"}` {
+		t.Errorf(`want {"2021-03-01","1999-12-31"}, got %v %v`, v, err)
+	}
+}
+
+func TestDateJSON(t *testing.T) {
+	t.Parallel()
+
+	type row struct {
+		D Date     `json:"d"`
+		N NullDate `json:"n"`
+	}
+
+	b, err := json.Marshal(row{D: Date{2021, time.March, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"d":"2021-03-01","n":null}` {
+		t.Errorf(`want {"d":"2021-03-01","n":null}, got %s`, b)
+	}
+
+	var r row
+	if err := json.Unmarshal([]byte(`{"d":"1999-12-31","n":"2000-01-01"}`), &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.D != (Date{1999, time.December, 31}) || r.N != NullDateFrom(Date{2000, time.January, 1}) {
+		t.Errorf("want 1999-12-31 and 2000-01-01, got %+v", r)
+	}
+}
+
+func TestDateRandomize(t *testing.T) {
+	t.Parallel()
+
+	seed := int64(0)
+	nextInt := func() int64 { seed++; return seed }
+
+	for i := 0; i < 100; i++ {
+		var d Date
+		d.Randomize(nextInt, "date", false)
+		if !d.IsValid() {
+			t.Fatalf("want a valid date, got %v", d)
+		}
+	}
+
+	var n NullDate
+	n.Randomize(nextInt, "date", true)
+	if n.Valid {
+		t.Error("want a NULL date")
+	}
+}
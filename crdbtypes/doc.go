@@ -0,0 +1,5 @@
+// Package crdbtypes holds the Go types the crdb driver maps CockroachDB column
+// types to when neither database/sql nor sqlboiler's types package has one.
+// They implement sql.Scanner, driver.Valuer and JSON marshalling, and
+// randomize.Randomizer for the generated tests.
+package crdbtypes
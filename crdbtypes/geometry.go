@@ -0,0 +1,769 @@
+package crdbtypes
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// GeometryType is the type of a Geometry, named as in GeoJSON.
+type GeometryType string
+
+// The geometry types of WKB and GeoJSON.
+const (
+	GeometryPoint              GeometryType = "Point"
+	GeometryLineString         GeometryType = "LineString"
+	GeometryPolygon            GeometryType = "Polygon"
+	GeometryMultiPoint         GeometryType = "MultiPoint"
+	GeometryMultiLineString    GeometryType = "MultiLineString"
+	GeometryMultiPolygon       GeometryType = "MultiPolygon"
+	GeometryGeometryCollection GeometryType = "GeometryCollection"
+)
+
+// wkbTypes are the geometry types of the WKB type codes.
+var wkbTypes = []GeometryType{
+	1: GeometryPoint,
+	2: GeometryLineString,
+	3: GeometryPolygon,
+	4: GeometryMultiPoint,
+	5: GeometryMultiLineString,
+	6: GeometryMultiPolygon,
+	7: GeometryGeometryCollection,
+}
+
+// The flags of the EWKB type codes.
+const (
+	ewkbZ    = 0x80000000
+	ewkbM    = 0x40000000
+	ewkbSRID = 0x20000000
+)
+
+// wgs84 is the SRID of GeoJSON coordinates and the default one of GEOGRAPHY.
+const wgs84 = 4326
+
+// Coord is the position of a point, x and y followed by z and m when the
+// geometry has them. x and y are the longitude and latitude in geographic
+// coordinates.
+type Coord []float64
+
+// Geometry is a GEOMETRY or GEOGRAPHY value. Which of Coords, Rings and
+// Geometries are set depends on the type:
+//
+//	Point: Coords holds the point, or nothing when it's empty
+//	LineString: Coords holds the points
+//	Polygon: Rings holds the exterior ring followed by the holes
+//	Multi* and GeometryCollection: Geometries holds the members
+//
+// The members of a Geometry have the SRID and dimensions of the Geometry.
+type Geometry struct {
+	Type GeometryType
+	SRID int
+	// Z and M tell whether the coordinates have a z or m value
+	Z, M bool
+
+	Coords     []Coord
+	Rings      [][]Coord
+	Geometries []Geometry
+}
+
+// NewPoint returns a Point of the SRID srid, 4326 for longitudes and
+// latitudes.
+func NewPoint(srid int, x, y float64) Geometry {
+	return Geometry{Type: GeometryPoint, SRID: srid, Coords: []Coord{{x, y}}}
+}
+
+// IsValid tells whether g has a geometry type, the zero Geometry hasn't.
+func (g Geometry) IsValid() bool {
+	return g.Type != ""
+}
+
+// dims returns the number of values of the coordinates of g.
+func (g Geometry) dims() int {
+	n := 2
+	if g.Z {
+		n++
+	}
+	if g.M {
+		n++
+	}
+	return n
+}
+
+// ParseEWKB decodes a geometry in the EWKB format of PostGIS and
+// CockroachDB, or in the WKB format, with or without the ISO codes of the
+// Z and M variants.
+func ParseEWKB(b []byte) (Geometry, error) {
+	r := &wkbReader{buf: b}
+	g, err := r.readGeometry(true)
+	if err != nil {
+		return Geometry{}, errors.Wrap(err, "crdbtypes: invalid EWKB")
+	}
+	if len(r.buf) != 0 {
+		return Geometry{}, errors.Errorf("crdbtypes: invalid EWKB, %d trailing bytes", len(r.buf))
+	}
+	return g, nil
+}
+
+// wkbReader reads the geometries of a WKB buffer, in the byte order of the
+// geometry being read.
+type wkbReader struct {
+	buf   []byte
+	order binary.ByteOrder
+}
+
+func (r *wkbReader) next(n int) ([]byte, error) {
+	if len(r.buf) < n {
+		return nil, errors.New("unexpected end of data")
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b, nil
+}
+
+func (r *wkbReader) readUint32() (uint32, error) {
+	b, err := r.next(4)
+	if err != nil {
+		return 0, err
+	}
+	return r.order.Uint32(b), nil
+}
+
+// readCount reads the number of items of size bytes at least that follow,
+// so that a corrupt count doesn't allocate more than the buffer holds.
+func (r *wkbReader) readCount(size int) (int, error) {
+	n, err := r.readUint32()
+	if err != nil {
+		return 0, err
+	}
+	if uint64(n)*uint64(size) > uint64(len(r.buf)) {
+		return 0, errors.Errorf("count %d exceeds the data", n)
+	}
+	return int(n), nil
+}
+
+func (r *wkbReader) readCoords(n, dims int) ([]Coord, error) {
+	coords := make([]Coord, n)
+	for i := range coords {
+		b, err := r.next(8 * dims)
+		if err != nil {
+			return nil, err
+		}
+		coords[i] = make(Coord, dims)
+		for j := range coords[i] {
+			coords[i][j] = math.Float64frombits(r.order.Uint64(b[8*j:]))
+		}
+	}
+	return coords, nil
+}
+
+func (r *wkbReader) readGeometry(top bool) (Geometry, error) {
+	var g Geometry
+
+	order, err := r.next(1)
+	if err != nil {
+		return g, err
+	}
+	switch order[0] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return g, errors.Errorf("invalid byte order %d", order[0])
+	}
+
+	code, err := r.readUint32()
+	if err != nil {
+		return g, err
+	}
+	g.Z, g.M = code&ewkbZ != 0, code&ewkbM != 0
+	if code&ewkbSRID != 0 {
+		if !top {
+			return g, errors.New("SRID of a member geometry")
+		}
+		srid, err := r.readUint32()
+		if err != nil {
+			return g, err
+		}
+		g.SRID = int(int32(srid))
+	}
+
+	code &^= ewkbZ | ewkbM | ewkbSRID
+	// ISO WKB adds 1000, 2000 or 3000 to the code for Z, M or both
+	switch code / 1000 {
+	case 1:
+		g.Z = true
+	case 2:
+		g.M = true
+	case 3:
+		g.Z, g.M = true, true
+	}
+	code %= 1000
+	if code == 0 || int(code) >= len(wkbTypes) {
+		return g, errors.Errorf("unknown geometry type %d", code)
+	}
+	g.Type = wkbTypes[code]
+
+	dims := g.dims()
+	switch g.Type {
+	case GeometryPoint:
+		coords, err := r.readCoords(1, dims)
+		if err != nil {
+			return g, err
+		}
+		// Empty points are written with NaN coordinates
+		if !math.IsNaN(coords[0][0]) || !math.IsNaN(coords[0][1]) {
+			g.Coords = coords
+		}
+	case GeometryLineString:
+		n, err := r.readCount(8 * dims)
+		if err != nil {
+			return g, err
+		}
+		if g.Coords, err = r.readCoords(n, dims); err != nil {
+			return g, err
+		}
+	case GeometryPolygon:
+		n, err := r.readCount(4)
+		if err != nil {
+			return g, err
+		}
+		g.Rings = make([][]Coord, n)
+		for i := range g.Rings {
+			m, err := r.readCount(8 * dims)
+			if err != nil {
+				return g, err
+			}
+			if g.Rings[i], err = r.readCoords(m, dims); err != nil {
+				return g, err
+			}
+		}
+	default:
+		n, err := r.readCount(5)
+		if err != nil {
+			return g, err
+		}
+		g.Geometries = make([]Geometry, n)
+		for i := range g.Geometries {
+			order := r.order
+			member, err := r.readGeometry(false)
+			if err != nil {
+				return g, err
+			}
+			r.order = order
+			if g.Type != GeometryGeometryCollection && member.Type != g.Type[len("Multi"):] {
+				return g, errors.Errorf("%s in a %s", member.Type, g.Type)
+			}
+			if member.Z != g.Z || member.M != g.M {
+				return g, errors.Errorf("mixed dimensions in a %s", g.Type)
+			}
+			member.setSRID(g.SRID)
+			g.Geometries[i] = member
+		}
+	}
+
+	return g, nil
+}
+
+// EWKB encodes g in the little endian EWKB format, with its SRID unless it's
+// 0.
+func (g Geometry) EWKB() []byte {
+	var buf bytes.Buffer
+	g.writeEWKB(&buf, true)
+	return buf.Bytes()
+}
+
+func (g Geometry) writeEWKB(buf *bytes.Buffer, top bool) {
+	var code uint32
+	for i, typ := range wkbTypes {
+		if typ == g.Type {
+			code = uint32(i)
+		}
+	}
+	if g.Z {
+		code |= ewkbZ
+	}
+	if g.M {
+		code |= ewkbM
+	}
+	if top && g.SRID != 0 {
+		code |= ewkbSRID
+	}
+
+	buf.WriteByte(1)
+	writeUint32(buf, code)
+	if top && g.SRID != 0 {
+		writeUint32(buf, uint32(g.SRID))
+	}
+
+	dims := g.dims()
+	switch g.Type {
+	case GeometryPoint:
+		if len(g.Coords) == 0 {
+			writeCoords(buf, []Coord{nil}, dims)
+		} else {
+			writeCoords(buf, g.Coords[:1], dims)
+		}
+	case GeometryLineString:
+		writeUint32(buf, uint32(len(g.Coords)))
+		writeCoords(buf, g.Coords, dims)
+	case GeometryPolygon:
+		writeUint32(buf, uint32(len(g.Rings)))
+		for _, ring := range g.Rings {
+			writeUint32(buf, uint32(len(ring)))
+			writeCoords(buf, ring, dims)
+		}
+	default:
+		writeUint32(buf, uint32(len(g.Geometries)))
+		for _, member := range g.Geometries {
+			member.Z, member.M = g.Z, g.M
+			member.writeEWKB(buf, false)
+		}
+	}
+}
+
+func writeUint32(buf *bytes.Buffer, v uint32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	buf.Write(b[:])
+}
+
+// writeCoords writes dims values per coordinate, missing values are NaN.
+func writeCoords(buf *bytes.Buffer, coords []Coord, dims int) {
+	var b [8]byte
+	for _, c := range coords {
+		for j := 0; j < dims; j++ {
+			v := math.NaN()
+			if j < len(c) {
+				v = c[j]
+			}
+			binary.LittleEndian.PutUint64(b[:], math.Float64bits(v))
+			buf.Write(b[:])
+		}
+	}
+}
+
+// Scan implements the sql.Scanner interface. CockroachDB sends geometries as
+// hex encoded EWKB, raw WKB is accepted too.
+func (g *Geometry) Scan(src interface{}) error {
+	var b []byte
+	switch src := src.(type) {
+	case string:
+		b = []byte(src)
+	case []byte:
+		b = src
+	default:
+		return errors.Errorf("crdbtypes: cannot scan %T into Geometry", src)
+	}
+
+	// Raw WKB starts with its byte order, hex with its first digit
+	if len(b) > 0 && b[0] > 1 {
+		raw := make([]byte, hex.DecodedLen(len(b)))
+		if _, err := hex.Decode(raw, b); err != nil {
+			return errors.Wrap(err, "crdbtypes: invalid hex EWKB")
+		}
+		b = raw
+	}
+
+	v, err := ParseEWKB(b)
+	if err != nil {
+		return err
+	}
+	*g = v
+	return nil
+}
+
+// Value implements the driver.Valuer interface, it writes the hex EWKB of g,
+// which CockroachDB parses for both GEOMETRY and GEOGRAPHY.
+func (g Geometry) Value() (driver.Value, error) {
+	if !g.IsValid() {
+		return nil, errors.New("crdbtypes: invalid Geometry")
+	}
+	return strings.ToUpper(hex.EncodeToString(g.EWKB())), nil
+}
+
+// geoJSON is the GeoJSON object of a geometry. The SRID is kept in the crs
+// member of the 2008 GeoJSON format when it isn't the WGS 84 of RFC 7946.
+type geoJSON struct {
+	Type        GeometryType    `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates,omitempty"`
+	Geometries  []geoJSON       `json:"geometries,omitempty"`
+	CRS         *geoJSONCRS     `json:"crs,omitempty"`
+}
+
+type geoJSONCRS struct {
+	Type       string `json:"type"`
+	Properties struct {
+		Name string `json:"name"`
+	} `json:"properties"`
+}
+
+// position returns the GeoJSON position of c, which has no m value.
+func (g Geometry) position(c Coord) []float64 {
+	if g.M && len(c) > 2 {
+		return c[:len(c)-1]
+	}
+	return c
+}
+
+func (g Geometry) positions(coords []Coord) [][]float64 {
+	ps := make([][]float64, len(coords))
+	for i, c := range coords {
+		ps[i] = g.position(c)
+	}
+	return ps
+}
+
+// coordinates returns the GeoJSON coordinates of g, nil for a collection.
+func (g Geometry) coordinates() interface{} {
+	switch g.Type {
+	case GeometryPoint:
+		if len(g.Coords) == 0 {
+			return []float64{}
+		}
+		return g.position(g.Coords[0])
+	case GeometryLineString:
+		return g.positions(g.Coords)
+	case GeometryPolygon:
+		rings := make([][][]float64, len(g.Rings))
+		for i, ring := range g.Rings {
+			rings[i] = g.positions(ring)
+		}
+		return rings
+	case GeometryGeometryCollection:
+		return nil
+	}
+
+	members := make([]interface{}, len(g.Geometries))
+	for i, member := range g.Geometries {
+		member.M = g.M
+		members[i] = member.coordinates()
+	}
+	return members
+}
+
+func (g Geometry) geoJSON() (geoJSON, error) {
+	obj := geoJSON{Type: g.Type}
+	if coords := g.coordinates(); coords != nil {
+		b, err := json.Marshal(coords)
+		if err != nil {
+			return obj, err
+		}
+		obj.Coordinates = b
+	} else {
+		obj.Geometries = make([]geoJSON, len(g.Geometries))
+		for i, member := range g.Geometries {
+			member.M = g.M
+			var err error
+			if obj.Geometries[i], err = member.geoJSON(); err != nil {
+				return obj, err
+			}
+		}
+	}
+	return obj, nil
+}
+
+// MarshalJSON implements json.Marshaler, it writes g as a GeoJSON geometry
+// without its m values.
+func (g Geometry) MarshalJSON() ([]byte, error) {
+	if !g.IsValid() {
+		return nil, errors.New("crdbtypes: invalid Geometry")
+	}
+	obj, err := g.geoJSON()
+	if err != nil {
+		return nil, err
+	}
+	if g.SRID != 0 && g.SRID != wgs84 {
+		obj.CRS = &geoJSONCRS{Type: "name"}
+		obj.CRS.Properties.Name = "EPSG:" + strconv.Itoa(g.SRID)
+	}
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, it reads a GeoJSON geometry.
+// The SRID is the one of its crs member, or 4326.
+func (g *Geometry) UnmarshalJSON(data []byte) error {
+	var obj geoJSON
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return errors.Wrap(err, "crdbtypes: invalid GeoJSON")
+	}
+
+	v, err := obj.geometry()
+	if err != nil {
+		return errors.Wrap(err, "crdbtypes: invalid GeoJSON")
+	}
+
+	srid := wgs84
+	if obj.CRS != nil {
+		name := obj.CRS.Properties.Name
+		if srid, err = strconv.Atoi(name[strings.LastIndexByte(name, ':')+1:]); err != nil {
+			return errors.Errorf("crdbtypes: invalid GeoJSON crs %q", name)
+		}
+	}
+	v.setSRID(srid)
+	*g = v
+	return nil
+}
+
+// setSRID sets the SRID of g and its members.
+func (g *Geometry) setSRID(srid int) {
+	g.SRID = srid
+	for i := range g.Geometries {
+		g.Geometries[i].setSRID(srid)
+	}
+}
+
+func toCoords(ps [][]float64) []Coord {
+	coords := make([]Coord, len(ps))
+	for i, p := range ps {
+		coords[i] = p
+	}
+	return coords
+}
+
+func (obj geoJSON) geometry() (Geometry, error) {
+	g := Geometry{Type: obj.Type}
+
+	var err error
+	switch obj.Type {
+	case GeometryPoint:
+		var p []float64
+		err = json.Unmarshal(obj.Coordinates, &p)
+		if len(p) > 0 {
+			g.Coords = []Coord{p}
+		}
+	case GeometryLineString:
+		var ps [][]float64
+		err = json.Unmarshal(obj.Coordinates, &ps)
+		g.Coords = toCoords(ps)
+	case GeometryPolygon:
+		var rings [][][]float64
+		err = json.Unmarshal(obj.Coordinates, &rings)
+		for _, ring := range rings {
+			g.Rings = append(g.Rings, toCoords(ring))
+		}
+	case GeometryMultiPoint, GeometryMultiLineString, GeometryMultiPolygon:
+		var members []json.RawMessage
+		err = json.Unmarshal(obj.Coordinates, &members)
+		for _, coords := range members {
+			member, merr := geoJSON{Type: obj.Type[len("Multi"):], Coordinates: coords}.geometry()
+			if merr != nil {
+				return g, merr
+			}
+			g.Geometries = append(g.Geometries, member)
+		}
+	case GeometryGeometryCollection:
+		for _, obj := range obj.Geometries {
+			member, merr := obj.geometry()
+			if merr != nil {
+				return g, merr
+			}
+			g.Geometries = append(g.Geometries, member)
+		}
+	default:
+		return g, errors.Errorf("unknown geometry type %q", obj.Type)
+	}
+	if err != nil {
+		return g, err
+	}
+
+	g.Z = g.hasZ()
+	return g, nil
+}
+
+// hasZ tells whether a coordinate of g has a z value.
+func (g Geometry) hasZ() bool {
+	for _, c := range g.Coords {
+		if len(c) > 2 {
+			return true
+		}
+	}
+	for _, ring := range g.Rings {
+		for _, c := range ring {
+			if len(c) > 2 {
+				return true
+			}
+		}
+	}
+	for _, member := range g.Geometries {
+		if member.hasZ() {
+			return true
+		}
+	}
+	return false
+}
+
+// Randomize implements randomize.Randomizer. The generated tests pass the
+// column type, as in geography(point,4326), so the geometries have the shape,
+// dimensions and SRID of the column. The points are valid longitudes and
+// latitudes.
+func (g *Geometry) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*g = Geometry{}
+		return
+	}
+	*g = randomGeometry(nextInt, fieldType)
+}
+
+// spatialShapes are the geometry types of the shapes of a column type.
+var spatialShapes = map[string]GeometryType{
+	"point":              GeometryPoint,
+	"linestring":         GeometryLineString,
+	"polygon":            GeometryPolygon,
+	"multipoint":         GeometryMultiPoint,
+	"multilinestring":    GeometryMultiLineString,
+	"multipolygon":       GeometryMultiPolygon,
+	"geometrycollection": GeometryGeometryCollection,
+	"geometry":           GeometryPoint,
+}
+
+// spatialType parses a column type like geometry, geography(point,4326) or
+// geometry(polygonzm) into the geometry type and dimensions of its shape and
+// its SRID. Columns of any shape get points.
+func spatialType(dbType string) (g Geometry) {
+	g.Type = GeometryPoint
+	if strings.HasPrefix(dbType, "geography") {
+		g.SRID = wgs84
+	}
+
+	open := strings.IndexByte(dbType, '(')
+	if open < 0 || !strings.HasSuffix(dbType, ")") {
+		return g
+	}
+	mods := strings.Split(dbType[open+1:len(dbType)-1], ",")
+
+	shape := strings.ToLower(strings.TrimSpace(mods[0]))
+	for _, suffix := range []string{"zm", "z", "m"} {
+		if _, ok := spatialShapes[shape]; !ok && strings.HasSuffix(shape, suffix) {
+			shape = strings.TrimSuffix(shape, suffix)
+			g.Z, g.M = strings.Contains(suffix, "z"), strings.Contains(suffix, "m")
+		}
+	}
+	if typ, ok := spatialShapes[shape]; ok {
+		g.Type = typ
+	}
+	if len(mods) > 1 {
+		if srid, err := strconv.Atoi(strings.TrimSpace(mods[1])); err == nil && srid != 0 {
+			g.SRID = srid
+		}
+	}
+	return g
+}
+
+func randomGeometry(nextInt func() int64, fieldType string) Geometry {
+	g := spatialType(fieldType)
+
+	coord := func(dx, dy float64, x, y, z, m float64) Coord {
+		c := Coord{x + dx, y + dy}
+		if g.Z {
+			c = append(c, z)
+		}
+		if g.M {
+			c = append(c, m)
+		}
+		return c
+	}
+	// The points stay in the longitude and latitude ranges, the shapes are
+	// small squares from them
+	x := float64(nextInt()%350000)/1000 - 175
+	y := float64(nextInt()%170000)/1000 - 85
+	z, m := float64(nextInt()%10000), float64(nextInt()%10000)
+	point := []Coord{coord(0, 0, x, y, z, m)}
+	line := []Coord{coord(0, 0, x, y, z, m), coord(0.001, 0.001, x, y, z, m)}
+	ring := []Coord{
+		coord(0, 0, x, y, z, m), coord(0.001, 0, x, y, z, m), coord(0.001, 0.001, x, y, z, m),
+		coord(0, 0.001, x, y, z, m), coord(0, 0, x, y, z, m),
+	}
+
+	member := Geometry{SRID: g.SRID, Z: g.Z, M: g.M}
+	switch g.Type {
+	case GeometryPoint:
+		g.Coords = point
+	case GeometryLineString:
+		g.Coords = line
+	case GeometryPolygon:
+		g.Rings = [][]Coord{ring}
+	case GeometryMultiPoint:
+		member.Type, member.Coords = GeometryPoint, point
+	case GeometryMultiLineString:
+		member.Type, member.Coords = GeometryLineString, line
+	case GeometryMultiPolygon:
+		member.Type, member.Rings = GeometryPolygon, [][]Coord{ring}
+	case GeometryGeometryCollection:
+		member.Type, member.Coords = GeometryPoint, point
+	}
+	if member.Type != "" {
+		g.Geometries = []Geometry{member}
+	}
+	return g
+}
+
+// NullGeometry is a Geometry that may be NULL.
+type NullGeometry struct {
+	Geometry Geometry
+	Valid    bool
+}
+
+// NewNullGeometry creates a new NullGeometry.
+func NewNullGeometry(g Geometry, valid bool) NullGeometry {
+	return NullGeometry{Geometry: g, Valid: valid}
+}
+
+// NullGeometryFrom creates a new NullGeometry that is never NULL.
+func NullGeometryFrom(g Geometry) NullGeometry {
+	return NewNullGeometry(g, true)
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *NullGeometry) Scan(src interface{}) error {
+	if src == nil {
+		*n = NullGeometry{}
+		return nil
+	}
+
+	n.Valid = true
+	return n.Geometry.Scan(src)
+}
+
+// Value implements the driver.Valuer interface.
+func (n NullGeometry) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Geometry.Value()
+}
+
+// MarshalJSON implements json.Marshaler, a NULL geometry is null.
+func (n NullGeometry) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.Geometry.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullGeometry) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = NullGeometry{}
+		return nil
+	}
+
+	n.Valid = true
+	return n.Geometry.UnmarshalJSON(data)
+}
+
+// Randomize implements randomize.Randomizer.
+func (n *NullGeometry) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*n = NullGeometry{}
+		return
+	}
+	*n = NullGeometryFrom(randomGeometry(nextInt, fieldType))
+}
@@ -0,0 +1,222 @@
+package crdbtypes
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestGeometryScanValue(t *testing.T) {
+	t.Parallel()
+
+	// SELECT 'SRID=4326;POINT(1 2)'::GEOGRAPHY
+	const hexPoint = "0101000020E6100000000000000000F03F0000000000000040"
+
+	var g Geometry
+	if err := g.Scan([]byte(hexPoint)); err != nil {
+		t.Fatal(err)
+	}
+	if want := NewPoint(4326, 1, 2); !reflect.DeepEqual(g, want) {
+		t.Errorf("want %+v, got %+v", want, g)
+	}
+	if v, err := g.Value(); err != nil || v != hexPoint {
+		t.Errorf("want %s, got %v %v", hexPoint, v, err)
+	}
+
+	// Raw big endian WKB of POINT(1 2), without SRID
+	raw := []byte{0, 0, 0, 0, 1, 0x3f, 0xf0, 0, 0, 0, 0, 0, 0, 0x40, 0, 0, 0, 0, 0, 0, 0}
+	if err := g.Scan(raw); err != nil || !reflect.DeepEqual(g, NewPoint(0, 1, 2)) {
+		t.Errorf("want POINT(1 2), got %+v %v", g, err)
+	}
+
+	if _, err := (Geometry{}).Value(); err == nil {
+		t.Error("want an error for the zero Geometry")
+	}
+	for _, in := range []string{"", "01", "0101000020E6100000000000000000F03F", "0109000000", "zz", hexPoint + "00"} {
+		if err := g.Scan(in); err == nil {
+			t.Errorf("%q: want an error", in)
+		}
+	}
+
+	var n NullGeometry
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("want an invalid NullGeometry, got %+v %v", n, err)
+	}
+	if v, err := n.Value(); err != nil || v != nil {
+		t.Errorf("want nil, got %v %v", v, err)
+	}
+}
+
+func TestGeometryEWKB(t *testing.T) {
+	t.Parallel()
+
+	square := []Coord{{0, 0, 1}, {1, 0, 1}, {1, 1, 1}, {0, 1, 1}, {0, 0, 1}}
+	tests := []Geometry{
+		{Type: GeometryPoint, SRID: 3857},
+		{Type: GeometryPoint, M: true, Coords: []Coord{{1, 2, 3}}},
+		{Type: GeometryLineString, SRID: 4326, Coords: []Coord{{1, 2}, {3, 4}}},
+		{Type: GeometryPolygon, SRID: 4326, Z: true, Rings: [][]Coord{square}},
+		{Type: GeometryMultiPolygon, SRID: 4326, Z: true, Geometries: []Geometry{
+			{Type: GeometryPolygon, SRID: 4326, Z: true, Rings: [][]Coord{square}},
+		}},
+		{Type: GeometryGeometryCollection, SRID: 4326, Geometries: []Geometry{
+			NewPoint(4326, 1, 2),
+			{Type: GeometryMultiPoint, SRID: 4326, Geometries: []Geometry{NewPoint(4326, 3, 4)}},
+		}},
+	}
+
+	for i, test := range tests {
+		got, err := ParseEWKB(test.EWKB())
+		if err != nil {
+			t.Errorf("%d: %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test) {
+			t.Errorf("%d: want %+v, got %+v", i, test, got)
+		}
+	}
+
+	// ISO WKB of POINT Z (1 2 3)
+	iso := []byte{1, 0xe9, 0x03, 0, 0}
+	for _, v := range []float64{1, 2, 3} {
+		b := math.Float64bits(v)
+		for i := 0; i < 8; i++ {
+			iso = append(iso, byte(b>>(8*i)))
+		}
+	}
+	if g, err := ParseEWKB(iso); err != nil || !g.Z || !reflect.DeepEqual(g.Coords, []Coord{{1, 2, 3}}) {
+		t.Errorf("want POINT Z (1 2 3), got %+v %v", g, err)
+	}
+
+	// A MultiPoint can't hold a LineString
+	bad := (Geometry{Type: GeometryMultiPoint, Geometries: []Geometry{{Type: GeometryLineString}}}).EWKB()
+	if _, err := ParseEWKB(bad); err == nil {
+		t.Error("want an error for a LineString in a MultiPoint")
+	}
+}
+
+func TestGeometryJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		g    Geometry
+		json string
+	}{
+		{NewPoint(4326, 1.5, 2), `{"type":"Point","coordinates":[1.5,2]}`},
+		{Geometry{Type: GeometryPoint, SRID: 4326}, `{"type":"Point","coordinates":[]}`},
+		{NewPoint(3857, 1, 2), `{"type":"Point","coordinates":[1,2],"crs":{"type":"name","properties":{"name":"EPSG:3857"}}}`},
+		{
+			Geometry{Type: GeometryLineString, SRID: 4326, Z: true, Coords: []Coord{{1, 2, 3}, {4, 5, 6}}},
+			`{"type":"LineString","coordinates":[[1,2,3],[4,5,6]]}`,
+		},
+		{
+			Geometry{Type: GeometryMultiPolygon, SRID: 4326, Geometries: []Geometry{
+				{Type: GeometryPolygon, SRID: 4326, Rings: [][]Coord{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}},
+			}},
+			`{"type":"MultiPolygon","coordinates":[[[[0,0],[1,0],[1,1],[0,0]]]]}`,
+		},
+		{
+			Geometry{Type: GeometryGeometryCollection, SRID: 4326, Geometries: []Geometry{
+				NewPoint(4326, 1, 2),
+				{Type: GeometryMultiPoint, SRID: 4326, Geometries: []Geometry{NewPoint(4326, 3, 4)}},
+			}},
+			`{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[1,2]},{"type":"MultiPoint","coordinates":[[3,4]]}]}`,
+		},
+	}
+
+	for i, test := range tests {
+		b, err := json.Marshal(test.g)
+		if err != nil {
+			t.Errorf("%d: %v", i, err)
+			continue
+		}
+		if string(b) != test.json {
+			t.Errorf("%d: want %s, got %s", i, test.json, b)
+		}
+
+		var got Geometry
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Errorf("%d: %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.g) {
+			t.Errorf("%d: want %+v back, got %+v", i, test.g, got)
+		}
+	}
+
+	// GeoJSON has no m values
+	m := Geometry{Type: GeometryPoint, SRID: 4326, M: true, Coords: []Coord{{1, 2, 3}}}
+	if b, err := json.Marshal(m); err != nil || string(b) != `{"type":"Point","coordinates":[1,2]}` {
+		t.Errorf("want the point without m, got %s %v", b, err)
+	}
+
+	type row struct {
+		N NullGeometry `json:"n"`
+	}
+	if b, err := json.Marshal(row{}); err != nil || string(b) != `{"n":null}` {
+		t.Errorf(`want {"n":null}, got %s %v`, b, err)
+	}
+	var r row
+	if err := json.Unmarshal([]byte(`{"n":{"type":"Point","coordinates":[1,2]}}`), &r); err != nil || !r.N.Valid {
+		t.Errorf("want a point, got %+v %v", r, err)
+	}
+
+	var g Geometry
+	for _, in := range []string{`{"type":"Circle"}`, `{"type":"Point","coordinates":"x"}`, `{"type":"Point","coordinates":[1,2],"crs":{"type":"name","properties":{"name":"EPSG:x"}}}`} {
+		if err := json.Unmarshal([]byte(in), &g); err == nil {
+			t.Errorf("%s: want an error", in)
+		}
+	}
+}
+
+func TestGeometryRandomize(t *testing.T) {
+	t.Parallel()
+
+	seed := int64(0)
+	nextInt := func() int64 { seed++; return seed }
+
+	tests := []struct {
+		fieldType string
+		typ       GeometryType
+		srid      int
+		z, m      bool
+	}{
+		{"geometry", GeometryPoint, 0, false, false},
+		{"geography", GeometryPoint, 4326, false, false},
+		{"geography(point,4326)", GeometryPoint, 4326, false, false},
+		{"geometry(point,3857)", GeometryPoint, 3857, false, false},
+		{"geometry(pointz)", GeometryPoint, 0, true, false},
+		{"geometry(polygonzm,4326)", GeometryPolygon, 4326, true, true},
+		{"geometry(linestringm)", GeometryLineString, 0, false, true},
+		{"geography(multipolygon,4326)", GeometryMultiPolygon, 4326, false, false},
+		{"geometry(geometrycollection)", GeometryGeometryCollection, 0, false, false},
+		{"geometry(geometry,4326)", GeometryPoint, 4326, false, false},
+	}
+
+	for _, test := range tests {
+		var g Geometry
+		g.Randomize(nextInt, test.fieldType, false)
+		if g.Type != test.typ || g.SRID != test.srid || g.Z != test.z || g.M != test.m {
+			t.Errorf("%s: want %s %d z %t m %t, got %+v", test.fieldType, test.typ, test.srid, test.z, test.m, g)
+			continue
+		}
+		if got, err := ParseEWKB(g.EWKB()); err != nil || !reflect.DeepEqual(got, g) {
+			t.Errorf("%s: want %+v back, got %+v %v", test.fieldType, g, got, err)
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		var g Geometry
+		g.Randomize(nextInt, "geography(point,4326)", false)
+		if x, y := g.Coords[0][0], g.Coords[0][1]; x < -180 || x > 180 || y < -90 || y > 90 {
+			t.Fatalf("want a longitude and latitude, got %v", g.Coords[0])
+		}
+	}
+
+	var n NullGeometry
+	n.Randomize(nextInt, "geometry", true)
+	if n.Valid {
+		t.Error("want a NULL geometry")
+	}
+}
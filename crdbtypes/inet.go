@@ -0,0 +1,245 @@
+package crdbtypes
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/netip"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// Inet is an INET value, an IP address with the length of the prefix of its
+// network, as in 192.168.0.1/24. The address of a host has a prefix of all its
+// bits, 32 or 128. Unlike with netip.ParsePrefix, the bits of the address
+// beyond the prefix are kept, Prefix.Masked() is the network of the address.
+type Inet struct {
+	Prefix netip.Prefix
+}
+
+// InetFromAddr returns the Inet of the host address a.
+func InetFromAddr(a netip.Addr) Inet {
+	return Inet{Prefix: netip.PrefixFrom(a, a.BitLen())}
+}
+
+// ParseInet parses an INET value, an IP address with an optional prefix
+// length, as in 192.168.0.1, 192.168.0.0/24 or 2001:db8::/32.
+func ParseInet(s string) (Inet, error) {
+	if p, err := netip.ParsePrefix(s); err == nil {
+		return Inet{Prefix: p}, nil
+	}
+
+	a, err := netip.ParseAddr(s)
+	if err != nil {
+		return Inet{}, errors.Wrapf(err, "crdbtypes: invalid inet %q", s)
+	}
+	return InetFromAddr(a), nil
+}
+
+// Addr returns the address of i.
+func (i Inet) Addr() netip.Addr {
+	return i.Prefix.Addr()
+}
+
+// IsValid tells whether i is a valid address, the zero Inet isn't.
+func (i Inet) IsValid() bool {
+	return i.Prefix.IsValid()
+}
+
+// IsHost tells whether the prefix has all the bits of the address, it's then
+// left out of String().
+func (i Inet) IsHost() bool {
+	return i.IsValid() && i.Prefix.Bits() == i.Prefix.Addr().BitLen()
+}
+
+// String returns the address, with its prefix length unless it's a host.
+func (i Inet) String() string {
+	if i.IsHost() {
+		return i.Addr().String()
+	}
+	return i.Prefix.String()
+}
+
+// Scan implements the sql.Scanner interface, NULL is the zero Inet.
+func (i *Inet) Scan(src interface{}) error {
+	var s string
+	switch src := src.(type) {
+	case nil:
+		*i = Inet{}
+		return nil
+	case string:
+		s = src
+	case []byte:
+		s = string(src)
+	default:
+		return errors.Errorf("crdbtypes: cannot scan %T into Inet", src)
+	}
+
+	v, err := ParseInet(s)
+	if err != nil {
+		return err
+	}
+	*i = v
+	return nil
+}
+
+// Value implements the driver.Valuer interface, the zero Inet is NULL like it's
+// empty in text.
+func (i Inet) Value() (driver.Value, error) {
+	if !i.IsValid() {
+		return nil, nil
+	}
+	return i.String(), nil
+}
+
+// MarshalText implements encoding.TextMarshaler, the zero Inet is empty.
+func (i Inet) MarshalText() ([]byte, error) {
+	if !i.IsValid() {
+		return []byte{}, nil
+	}
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (i *Inet) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*i = Inet{}
+		return nil
+	}
+
+	v, err := ParseInet(string(text))
+	if err != nil {
+		return err
+	}
+	*i = v
+	return nil
+}
+
+// Randomize implements randomize.Randomizer, the addresses are hosts of
+// 10.0.0.0/8.
+func (i *Inet) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*i = Inet{}
+		return
+	}
+	*i = randomInet(nextInt)
+}
+
+func randomInet(nextInt func() int64) Inet {
+	n := nextInt()
+	return InetFromAddr(netip.AddrFrom4([4]byte{10, byte(n >> 16), byte(n >> 8), byte(n)}))
+}
+
+// NullInet is an Inet that may be NULL.
+type NullInet struct {
+	Inet  Inet
+	Valid bool
+}
+
+// NewNullInet creates a new NullInet.
+func NewNullInet(i Inet, valid bool) NullInet {
+	return NullInet{Inet: i, Valid: valid}
+}
+
+// NullInetFrom creates a new NullInet that is never NULL.
+func NullInetFrom(i Inet) NullInet {
+	return NewNullInet(i, true)
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *NullInet) Scan(src interface{}) error {
+	if src == nil {
+		*n = NullInet{}
+		return nil
+	}
+
+	n.Valid = true
+	return n.Inet.Scan(src)
+}
+
+// Value implements the driver.Valuer interface.
+func (n NullInet) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Inet.Value()
+}
+
+// MarshalJSON implements json.Marshaler, a NULL inet is null.
+func (n NullInet) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.Inet)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullInet) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = NullInet{}
+		return nil
+	}
+
+	n.Valid = true
+	return json.Unmarshal(data, &n.Inet)
+}
+
+// Randomize implements randomize.Randomizer.
+func (n *NullInet) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*n = NullInet{}
+		return
+	}
+	*n = NullInetFrom(randomInet(nextInt))
+}
+
+// InetArray is an INET[] value, a nil slice is NULL.
+type InetArray []Inet
+
+// Scan implements the sql.Scanner interface.
+func (a *InetArray) Scan(src interface{}) error {
+	var strs pq.StringArray
+	if err := strs.Scan(src); err != nil {
+		return errors.Wrap(err, "crdbtypes: cannot scan InetArray")
+	}
+	if strs == nil {
+		*a = nil
+		return nil
+	}
+
+	inets := make(InetArray, len(strs))
+	for n, s := range strs {
+		v, err := ParseInet(s)
+		if err != nil {
+			return err
+		}
+		inets[n] = v
+	}
+	*a = inets
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (a InetArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+
+	strs := make(pq.StringArray, len(a))
+	for n, i := range a {
+		if !i.IsValid() {
+			return nil, errors.New("crdbtypes: invalid Inet in InetArray")
+		}
+		strs[n] = i.String()
+	}
+	return strs.Value()
+}
+
+// Randomize implements randomize.Randomizer.
+func (a *InetArray) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*a = nil
+		return
+	}
+	*a = InetArray{randomInet(nextInt)}
+}
@@ -0,0 +1,130 @@
+package crdbtypes
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+)
+
+func TestParseInet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want Inet
+		host bool
+	}{
+		{"192.168.0.1", Inet{Prefix: netip.MustParsePrefix("192.168.0.1/32")}, true},
+		{"192.168.0.1/24", Inet{Prefix: netip.MustParsePrefix("192.168.0.1/24")}, false},
+		{"10.0.0.0/8", Inet{Prefix: netip.MustParsePrefix("10.0.0.0/8")}, false},
+		{"2001:db8::1", Inet{Prefix: netip.MustParsePrefix("2001:db8::1/128")}, true},
+		{"2001:db8::/32", Inet{Prefix: netip.MustParsePrefix("2001:db8::/32")}, false},
+	}
+
+	for _, test := range tests {
+		got, err := ParseInet(test.in)
+		if err != nil {
+			t.Errorf("%s: %v", test.in, err)
+			continue
+		}
+		if got != test.want || got.IsHost() != test.host {
+			t.Errorf("%s: want %v host %t, got %v host %t", test.in, test.want, test.host, got, got.IsHost())
+		}
+		if got.String() != test.in {
+			t.Errorf("%s: got %s back", test.in, got)
+		}
+	}
+
+	for _, in := range []string{"", "192.168.0", "192.168.0.1/33", "example.com"} {
+		if _, err := ParseInet(in); err == nil {
+			t.Errorf("%q: want an error", in)
+		}
+	}
+}
+
+func TestInetScanValue(t *testing.T) {
+	t.Parallel()
+
+	var i Inet
+	if err := i.Scan([]byte("192.168.0.1/24")); err != nil {
+		t.Fatal(err)
+	}
+	if i.Addr() != netip.MustParseAddr("192.168.0.1") || i.Prefix.Masked() != netip.MustParsePrefix("192.168.0.0/24") {
+		t.Errorf("want 192.168.0.1 in 192.168.0.0/24, got %v", i)
+	}
+	if v, err := i.Value(); err != nil || v != "192.168.0.1/24" {
+		t.Errorf("want 192.168.0.1/24, got %v %v", v, err)
+	}
+	if v, err := (Inet{}).Value(); err != nil || v != nil {
+		t.Errorf("want NULL for the zero Inet, got %v %v", v, err)
+	}
+	if err := i.Scan(nil); err != nil || i.IsValid() {
+		t.Errorf("want the zero Inet for NULL, got %v %v", i, err)
+	}
+
+	var n NullInet
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("want an invalid NullInet, got %+v %v", n, err)
+	}
+	if v, err := n.Value(); err != nil || v != nil {
+		t.Errorf("want nil, got %v %v", v, err)
+	}
+	if err := n.Scan("::1"); err != nil || n != NullInetFrom(InetFromAddr(netip.IPv6Loopback())) {
+		t.Errorf("want ::1, got %+v %v", n, err)
+	}
+
+	var a InetArray
+	if err := a.Scan([]byte(`{10.0.0.1,10.0.0.0/8}`)); err != nil {
+		t.Fatal(err)
+	}
+	if len(a) != 2 || a[0].String() != "10.0.0.1" || a[1].String() != "10.0.0.0/8" {
+		t.Errorf("want [10.0.0.1 10.0.0.0/8], got %v", a)
+	}
+	if v, err := a.Value(); err != nil || v != `{"10.0.0.1","10.0.0.0/8"}` {
+		t.Errorf(`want {"10.0.0.1","10.0.0.0/8"}, got %v %v`, v, err)
+	}
+}
+
+func TestInetJSON(t *testing.T) {
+	t.Parallel()
+
+	type row struct {
+		I Inet     `json:"i"`
+		N NullInet `json:"n"`
+	}
+
+	b, err := json.Marshal(row{I: InetFromAddr(netip.MustParseAddr("10.1.2.3"))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"i":"10.1.2.3","n":null}` {
+		t.Errorf(`want {"i":"10.1.2.3","n":null}, got %s`, b)
+	}
+
+	var r row
+	if err := json.Unmarshal([]byte(`{"i":"10.0.0.0/8","n":"::1"}`), &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.I.String() != "10.0.0.0/8" || !r.N.Valid || r.N.Inet.String() != "::1" {
+		t.Errorf("want 10.0.0.0/8 and ::1, got %+v", r)
+	}
+}
+
+func TestInetRandomize(t *testing.T) {
+	t.Parallel()
+
+	seed := int64(0)
+	nextInt := func() int64 { seed++; return seed }
+
+	var i Inet
+	i.Randomize(nextInt, "inet", false)
+	if !i.IsHost() || !netip.MustParsePrefix("10.0.0.0/8").Contains(i.Addr()) {
+		t.Errorf("want a host of 10.0.0.0/8, got %v", i)
+	}
+
+	var n NullInet
+	n.Randomize(nextInt, "inet", true)
+	if n.Valid {
+		t.Error("want a NULL inet")
+	}
+}
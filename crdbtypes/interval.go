@@ -0,0 +1,452 @@
+package crdbtypes
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// Interval is an INTERVAL value. Months and days are kept apart from the
+// nanoseconds because how long they are depends on the date they are added
+// to, a month isn't always 30 days and a day isn't always 24 hours.
+type Interval struct {
+	Months int64
+	Days   int64
+	Nanos  int64
+}
+
+// intervalUnits are the lengths of the units of the postgres interval style,
+// in months, days or nanoseconds.
+var intervalUnits = map[string]Interval{
+	"year":    {Months: 12},
+	"years":   {Months: 12},
+	"mon":     {Months: 1},
+	"mons":    {Months: 1},
+	"month":   {Months: 1},
+	"months":  {Months: 1},
+	"week":    {Days: 7},
+	"weeks":   {Days: 7},
+	"day":     {Days: 1},
+	"days":    {Days: 1},
+	"hour":    {Nanos: int64(time.Hour)},
+	"hours":   {Nanos: int64(time.Hour)},
+	"min":     {Nanos: int64(time.Minute)},
+	"mins":    {Nanos: int64(time.Minute)},
+	"minute":  {Nanos: int64(time.Minute)},
+	"minutes": {Nanos: int64(time.Minute)},
+	"sec":     {Nanos: int64(time.Second)},
+	"secs":    {Nanos: int64(time.Second)},
+	"second":  {Nanos: int64(time.Second)},
+	"seconds": {Nanos: int64(time.Second)},
+}
+
+// IntervalFromDuration returns the interval of d, which has no months or days.
+func IntervalFromDuration(d time.Duration) Interval {
+	return Interval{Nanos: int64(d)}
+}
+
+// ParseInterval parses an interval in the postgres interval style, as in
+// "1 year 2 mons 3 days 04:05:06.789", or in the ISO 8601 one, as in
+// "P1Y2M3DT4H5M6.789S". Those are the styles CockroachDB returns intervals in
+// with the intervalstyle session setting at postgres or iso_8601.
+func ParseInterval(s string) (Interval, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "P") {
+		i, err := parseISO8601Interval(s[1:])
+		return i, errors.Wrapf(err, "crdbtypes: invalid interval %q", s)
+	}
+
+	var i Interval
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return Interval{}, errors.New("crdbtypes: empty interval")
+	}
+	for n := 0; n < len(fields); n++ {
+		if strings.Contains(fields[n], ":") {
+			nanos, err := parseClock(fields[n])
+			if err != nil {
+				return Interval{}, errors.Wrapf(err, "crdbtypes: invalid interval %q", s)
+			}
+			i.Nanos += nanos
+			continue
+		}
+
+		if n+1 == len(fields) {
+			return Interval{}, errors.Errorf("crdbtypes: invalid interval %q, %s has no unit", s, fields[n])
+		}
+		v, err := strconv.ParseInt(fields[n], 10, 64)
+		if err != nil {
+			return Interval{}, errors.Wrapf(err, "crdbtypes: invalid interval %q", s)
+		}
+		unit, ok := intervalUnits[strings.ToLower(fields[n+1])]
+		if !ok {
+			return Interval{}, errors.Errorf("crdbtypes: invalid interval %q, unknown unit %s", s, fields[n+1])
+		}
+		i.Months += v * unit.Months
+		i.Days += v * unit.Days
+		i.Nanos += v * unit.Nanos
+		n++
+	}
+
+	return i, nil
+}
+
+// parseISO8601Interval parses the designators of an ISO 8601 interval, what
+// follows its P.
+func parseISO8601Interval(s string) (Interval, error) {
+	var i Interval
+	inTime := false
+	for len(s) > 0 {
+		if s[0] == 'T' {
+			inTime = true
+			s = s[1:]
+			continue
+		}
+
+		end := strings.IndexAny(s, "YMWDHS")
+		if end <= 0 {
+			return Interval{}, errors.Errorf("missing designator after %q", s)
+		}
+		number, designator := s[:end], s[end]
+		s = s[end+1:]
+
+		if designator == 'S' {
+			nanos, err := parseSeconds(number)
+			if err != nil {
+				return Interval{}, err
+			}
+			i.Nanos += nanos
+			continue
+		}
+
+		v, err := strconv.ParseInt(number, 10, 64)
+		if err != nil {
+			return Interval{}, err
+		}
+		switch {
+		case designator == 'Y' && !inTime:
+			i.Months += 12 * v
+		case designator == 'M' && !inTime:
+			i.Months += v
+		case designator == 'W' && !inTime:
+			i.Days += 7 * v
+		case designator == 'D' && !inTime:
+			i.Days += v
+		case designator == 'H' && inTime:
+			i.Nanos += v * int64(time.Hour)
+		case designator == 'M' && inTime:
+			i.Nanos += v * int64(time.Minute)
+		default:
+			return Interval{}, errors.Errorf("unexpected designator %c", designator)
+		}
+	}
+
+	return i, nil
+}
+
+// parseClock parses the [-]hh:mm[:ss[.fraction]] part of an interval into
+// nanoseconds.
+func parseClock(s string) (int64, error) {
+	sign := int64(1)
+	switch s[0] {
+	case '-':
+		sign = -1
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return 0, errors.Errorf("invalid time %q", s)
+	}
+	hours, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	minutes, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	var seconds int64
+	if len(parts) == 3 {
+		if seconds, err = parseSeconds(parts[2]); err != nil {
+			return 0, err
+		}
+	}
+
+	return sign * (hours*int64(time.Hour) + minutes*int64(time.Minute) + seconds), nil
+}
+
+// parseSeconds parses seconds with up to nine fractional digits into
+// nanoseconds, as in 6.789.
+func parseSeconds(s string) (int64, error) {
+	whole, fraction := s, ""
+	if dot := strings.IndexByte(s, '.'); dot >= 0 {
+		whole, fraction = s[:dot], s[dot+1:]
+	}
+	if len(fraction) > 9 {
+		fraction = fraction[:9]
+	}
+
+	negative := strings.HasPrefix(whole, "-")
+	seconds, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	var nanos int64
+	if fraction != "" {
+		if nanos, err = strconv.ParseInt(fraction+strings.Repeat("0", 9-len(fraction)), 10, 64); err != nil {
+			return 0, err
+		}
+	}
+	if negative {
+		nanos = -nanos
+	}
+
+	return seconds*int64(time.Second) + nanos, nil
+}
+
+// Duration returns the interval as a time.Duration. It returns false when the
+// interval has months or days, which don't have a fixed length.
+func (i Interval) Duration() (time.Duration, bool) {
+	if i.Months != 0 || i.Days != 0 {
+		return 0, false
+	}
+	return time.Duration(i.Nanos), true
+}
+
+// String returns the interval in the postgres interval style, as in
+// "1 year 2 mons 3 days 04:05:06.789".
+func (i Interval) String() string {
+	var parts []string
+	negative := false
+	add := func(v int64, unit string) {
+		if v == 0 {
+			return
+		}
+		part := fmt.Sprintf("%d %s", v, unit)
+		// Like PostgreSQL, only 1 is singular, as in "-1 years".
+		if v != 1 {
+			part += "s"
+		}
+		// The positive fields after a negative one get a sign too.
+		if v > 0 && negative {
+			part = "+" + part
+		}
+		negative = negative || v < 0
+		parts = append(parts, part)
+	}
+	add(i.Months/12, "year")
+	add(i.Months%12, "mon")
+	add(i.Days, "day")
+
+	if i.Nanos != 0 || len(parts) == 0 {
+		parts = append(parts, formatClock(i.Nanos, negative))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// formatClock formats nanoseconds as [-]hh:mm:ss[.fraction], plus gets a sign
+// when the time is positive.
+func formatClock(nanos int64, plus bool) string {
+	sign := ""
+	// Negated as an uint64, so that the smallest int64 doesn't overflow
+	abs := uint64(nanos)
+	if nanos < 0 {
+		sign = "-"
+		abs = -abs
+	} else if plus && nanos > 0 {
+		sign = "+"
+	}
+
+	seconds := abs / uint64(time.Second)
+	clock := fmt.Sprintf("%s%02d:%02d:%02d", sign, seconds/3600, seconds/60%60, seconds%60)
+	if fraction := abs % uint64(time.Second); fraction != 0 {
+		clock += "." + strings.TrimRight(fmt.Sprintf("%09d", fraction), "0")
+	}
+
+	return clock
+}
+
+// Scan implements the sql.Scanner interface.
+func (i *Interval) Scan(src interface{}) error {
+	var s string
+	switch src := src.(type) {
+	case string:
+		s = src
+	case []byte:
+		s = string(src)
+	default:
+		return errors.Errorf("crdbtypes: cannot scan %T into Interval", src)
+	}
+
+	v, err := ParseInterval(s)
+	if err != nil {
+		return err
+	}
+	*i = v
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (i Interval) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+// MarshalJSON implements json.Marshaler, the interval is a string in the
+// postgres interval style.
+func (i Interval) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *Interval) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return errors.Wrap(err, "crdbtypes: interval must be a string")
+	}
+
+	v, err := ParseInterval(s)
+	if err != nil {
+		return err
+	}
+	*i = v
+	return nil
+}
+
+// Randomize implements randomize.Randomizer. The intervals have whole
+// microseconds, the precision CockroachDB keeps.
+func (i *Interval) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*i = Interval{}
+		return
+	}
+	*i = randomInterval(nextInt)
+}
+
+func randomInterval(nextInt func() int64) Interval {
+	return Interval{
+		Months: nextInt() % 24,
+		Days:   1 + nextInt()%30,
+		Nanos:  nextInt() % int64(24*time.Hour/time.Microsecond) * int64(time.Microsecond),
+	}
+}
+
+// NullInterval is an Interval that may be NULL.
+type NullInterval struct {
+	Interval Interval
+	Valid    bool
+}
+
+// NewNullInterval creates a new NullInterval.
+func NewNullInterval(i Interval, valid bool) NullInterval {
+	return NullInterval{Interval: i, Valid: valid}
+}
+
+// NullIntervalFrom creates a new NullInterval that is never NULL.
+func NullIntervalFrom(i Interval) NullInterval {
+	return NewNullInterval(i, true)
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *NullInterval) Scan(src interface{}) error {
+	if src == nil {
+		*n = NullInterval{}
+		return nil
+	}
+
+	n.Valid = true
+	return n.Interval.Scan(src)
+}
+
+// Value implements the driver.Valuer interface.
+func (n NullInterval) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Interval.Value()
+}
+
+// MarshalJSON implements json.Marshaler, a NULL interval is null.
+func (n NullInterval) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.Interval.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullInterval) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = NullInterval{}
+		return nil
+	}
+
+	n.Valid = true
+	return n.Interval.UnmarshalJSON(data)
+}
+
+// Randomize implements randomize.Randomizer.
+func (n *NullInterval) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*n = NullInterval{}
+		return
+	}
+	*n = NullIntervalFrom(randomInterval(nextInt))
+}
+
+// IntervalArray is an INTERVAL[] value, a nil slice is NULL.
+type IntervalArray []Interval
+
+// Scan implements the sql.Scanner interface.
+func (a *IntervalArray) Scan(src interface{}) error {
+	var strs pq.StringArray
+	if err := strs.Scan(src); err != nil {
+		return errors.Wrap(err, "crdbtypes: cannot scan IntervalArray")
+	}
+	if strs == nil {
+		*a = nil
+		return nil
+	}
+
+	intervals := make(IntervalArray, len(strs))
+	for n, s := range strs {
+		v, err := ParseInterval(s)
+		if err != nil {
+			return err
+		}
+		intervals[n] = v
+	}
+	*a = intervals
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (a IntervalArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+
+	strs := make(pq.StringArray, len(a))
+	for n, i := range a {
+		strs[n] = i.String()
+	}
+	return strs.Value()
+}
+
+// Randomize implements randomize.Randomizer.
+func (a *IntervalArray) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*a = nil
+		return
+	}
+	*a = IntervalArray{randomInterval(nextInt)}
+}
@@ -0,0 +1,175 @@
+package crdbtypes
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseInterval(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want Interval
+	}{
+		{"00:00:00", Interval{}},
+		{"21 days", Interval{Days: 21}},
+		{"23:00:00", Interval{Nanos: int64(23 * time.Hour)}},
+		{"1 year 2 mons 3 days 04:05:06.789", Interval{14, 3, int64(4*time.Hour + 5*time.Minute + 6789*time.Millisecond)}},
+		{"-1 years -2 mons +3 days -04:05:06", Interval{-14, 3, -int64(4*time.Hour + 5*time.Minute + 6*time.Second)}},
+		{"1 mon -00:00:00.000001", Interval{1, 0, -1000}},
+		{"2 weeks 3 hours", Interval{Days: 14, Nanos: int64(3 * time.Hour)}},
+		{"P1Y2M3DT4H5M6.789S", Interval{14, 3, int64(4*time.Hour + 5*time.Minute + 6789*time.Millisecond)}},
+		{"P-1Y-2M3DT-4H-5M-6S", Interval{-14, 3, -int64(4*time.Hour + 5*time.Minute + 6*time.Second)}},
+		{"PT-0.5S", Interval{Nanos: -int64(500 * time.Millisecond)}},
+		{"P2W", Interval{Days: 14}},
+	}
+
+	for _, test := range tests {
+		got, err := ParseInterval(test.in)
+		if err != nil {
+			t.Errorf("%s: %v", test.in, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%s: want %+v, got %+v", test.in, test.want, got)
+		}
+	}
+
+	for _, in := range []string{"", "3", "3 fortnights", "1:2:3:4", "P3", "PT3D", "P1H"} {
+		if _, err := ParseInterval(in); err == nil {
+			t.Errorf("%q: want an error", in)
+		}
+	}
+}
+
+func TestIntervalString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   Interval
+		want string
+	}{
+		{Interval{}, "00:00:00"},
+		{Interval{Days: 21}, "21 days"},
+		{Interval{Months: 14, Days: 1, Nanos: int64(4*time.Hour + 5*time.Minute + 6789*time.Millisecond)}, "1 year 2 mons 1 day 04:05:06.789"},
+		{Interval{Months: -14, Days: 3, Nanos: int64(time.Hour)}, "-1 years -2 mons +3 days +01:00:00"},
+		{Interval{Nanos: int64(-25 * time.Hour)}, "-25:00:00"},
+		{Interval{Nanos: math.MinInt64}, "-2562047:47:16.854775808"},
+	}
+
+	for _, test := range tests {
+		got := test.in.String()
+		if got != test.want {
+			t.Errorf("%+v: want %q, got %q", test.in, test.want, got)
+		}
+		if back, err := ParseInterval(got); err != nil || back != test.in {
+			t.Errorf("%q: want %+v back, got %+v %v", got, test.in, back, err)
+		}
+	}
+}
+
+func TestIntervalDuration(t *testing.T) {
+	t.Parallel()
+
+	if d, ok := IntervalFromDuration(90 * time.Minute).Duration(); !ok || d != 90*time.Minute {
+		t.Errorf("want 1h30m0s true, got %v %t", d, ok)
+	}
+	if _, ok := (Interval{Days: 1}).Duration(); ok {
+		t.Error("a day has no fixed duration")
+	}
+	if _, ok := (Interval{Months: 1}).Duration(); ok {
+		t.Error("a month has no fixed duration")
+	}
+}
+
+func TestIntervalScanValue(t *testing.T) {
+	t.Parallel()
+
+	var i Interval
+	if err := i.Scan([]byte("3 days 01:00:00")); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := i.Value(); err != nil || v != "3 days 01:00:00" {
+		t.Errorf("want 3 days 01:00:00, got %v %v", v, err)
+	}
+	if err := i.Scan(nil); err == nil {
+		t.Error("want an error scanning NULL into an Interval")
+	}
+
+	var n NullInterval
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("want an invalid NullInterval, got %+v %v", n, err)
+	}
+	if v, err := n.Value(); err != nil || v != nil {
+		t.Errorf("want nil, got %v %v", v, err)
+	}
+	if err := n.Scan("1 day"); err != nil || n != NullIntervalFrom(Interval{Days: 1}) {
+		t.Errorf("want 1 day, got %+v %v", n, err)
+	}
+
+	var a IntervalArray
+	if err := a.Scan([]byte(`{"1 day","-00:00:01"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if len(a) != 2 || a[0] != (Interval{Days: 1}) || a[1] != (Interval{Nanos: -int64(time.Second)}) {
+		t.Errorf("want [1 day -00:00:01], got %v", a)
+	}
+	if v, err := a.Value(); err != nil || v != `{"1 day","-00:00:01"}` {
+		t.Errorf(`want {"1 day","-00:00:01"}, got %v %v`, v, err)
+	}
+	if err := a.Scan(nil); err != nil || a != nil {
+		t.Errorf("want a nil IntervalArray, got %v %v", a, err)
+	}
+}
+
+func TestIntervalJSON(t *testing.T) {
+	t.Parallel()
+
+	type row struct {
+		I Interval     `json:"i"`
+		N NullInterval `json:"n"`
+	}
+
+	b, err := json.Marshal(row{I: Interval{Days: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"i":"2 days","n":null}` {
+		t.Errorf(`want {"i":"2 days","n":null}, got %s`, b)
+	}
+
+	var r row
+	if err := json.Unmarshal([]byte(`{"i":"PT1H","n":"1 mon"}`), &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.I != IntervalFromDuration(time.Hour) || r.N != NullIntervalFrom(Interval{Months: 1}) {
+		t.Errorf("want 1 hour and 1 month, got %+v", r)
+	}
+}
+
+func TestIntervalRandomize(t *testing.T) {
+	t.Parallel()
+
+	seed := int64(0)
+	nextInt := func() int64 { seed += 1000003; return seed }
+
+	for n := 0; n < 100; n++ {
+		var i Interval
+		i.Randomize(nextInt, "interval", false)
+		if i == (Interval{}) || i.Nanos%int64(time.Microsecond) != 0 {
+			t.Fatalf("want a non-zero interval of whole microseconds, got %+v", i)
+		}
+		if back, err := ParseInterval(i.String()); err != nil || back != i {
+			t.Fatalf("%+v: got %+v back, %v", i, back, err)
+		}
+	}
+
+	var n NullInterval
+	n.Randomize(nextInt, "interval", true)
+	if n.Valid {
+		t.Error("want a NULL interval")
+	}
+}
@@ -0,0 +1,537 @@
+package crdbtypes
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// TimeOfDay is a TIME value, a time of the day without date or time zone.
+// 24:00:00 is the end of the day, as in PostgreSQL.
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// TimeOfDayOf returns the time of the day of t, in the location of t.
+// lib/pq reads TIME values as a time.Time of the year 0, on January 2nd for
+// 24:00:00.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	if t.Year() == 0 && t.YearDay() == 2 && t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		return TimeOfDay{Hour: 24}
+	}
+	return TimeOfDay{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second(), Nanosecond: t.Nanosecond()}
+}
+
+// ParseTimeOfDay parses a time of the day as in 15:04, 15:04:05 or
+// 15:04:05.999999.
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	var t TimeOfDay
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 && len(parts) != 3 {
+		return TimeOfDay{}, errors.Errorf("crdbtypes: invalid time of day %q", s)
+	}
+
+	var err error
+	if t.Hour, err = parseTimeField(parts[0]); err != nil {
+		return TimeOfDay{}, errors.Wrapf(err, "crdbtypes: invalid time of day %q", s)
+	}
+	if t.Minute, err = parseTimeField(parts[1]); err != nil {
+		return TimeOfDay{}, errors.Wrapf(err, "crdbtypes: invalid time of day %q", s)
+	}
+	if len(parts) == 3 {
+		sec := parts[2]
+		if i := strings.IndexByte(sec, '.'); i >= 0 {
+			frac := sec[i+1:]
+			if len(frac) == 0 || len(frac) > 9 {
+				return TimeOfDay{}, errors.Errorf("crdbtypes: invalid time of day %q", s)
+			}
+			if t.Nanosecond, err = parseTimeField(frac + strings.Repeat("0", 9-len(frac))); err != nil {
+				return TimeOfDay{}, errors.Wrapf(err, "crdbtypes: invalid time of day %q", s)
+			}
+			sec = sec[:i]
+		}
+		if t.Second, err = parseTimeField(sec); err != nil {
+			return TimeOfDay{}, errors.Wrapf(err, "crdbtypes: invalid time of day %q", s)
+		}
+	}
+
+	if !t.IsValid() {
+		return TimeOfDay{}, errors.Errorf("crdbtypes: invalid time of day %q", s)
+	}
+	return t, nil
+}
+
+// parseTimeField parses the digits of a field of a time, without sign.
+func parseTimeField(s string) (int, error) {
+	if s == "" || s[0] == '+' || s[0] == '-' {
+		return 0, errors.Errorf("invalid field %q", s)
+	}
+	return strconv.Atoi(s)
+}
+
+// IsValid tells whether t is a time of the day, between 00:00:00 and 24:00:00.
+func (t TimeOfDay) IsValid() bool {
+	if t.Hour == 24 {
+		return t.Minute == 0 && t.Second == 0 && t.Nanosecond == 0
+	}
+	return t.Hour >= 0 && t.Hour < 24 &&
+		t.Minute >= 0 && t.Minute < 60 &&
+		t.Second >= 0 && t.Second < 60 &&
+		t.Nanosecond >= 0 && t.Nanosecond < int(time.Second)
+}
+
+// Duration returns the time elapsed since the start of the day.
+func (t TimeOfDay) Duration() time.Duration {
+	return time.Duration(t.Hour)*time.Hour +
+		time.Duration(t.Minute)*time.Minute +
+		time.Duration(t.Second)*time.Second +
+		time.Duration(t.Nanosecond)
+}
+
+// On returns the time t of the day d in loc.
+func (t TimeOfDay) On(d Date, loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, t.Hour, t.Minute, t.Second, t.Nanosecond, loc)
+}
+
+// String returns the time in the 15:04:05 format, with as many fractional
+// digits as needed.
+func (t TimeOfDay) String() string {
+	s := fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	if t.Nanosecond != 0 {
+		s += strings.TrimRight(fmt.Sprintf(".%09d", t.Nanosecond), "0")
+	}
+	return s
+}
+
+// Scan implements the sql.Scanner interface.
+func (t *TimeOfDay) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case time.Time:
+		*t = TimeOfDayOf(src)
+		return nil
+	case string:
+		return t.UnmarshalText([]byte(src))
+	case []byte:
+		return t.UnmarshalText(src)
+	}
+	return errors.Errorf("crdbtypes: cannot scan %T into TimeOfDay", src)
+}
+
+// Value implements the driver.Valuer interface.
+func (t TimeOfDay) Value() (driver.Value, error) {
+	if !t.IsValid() {
+		return nil, errors.Errorf("crdbtypes: invalid TimeOfDay %s", t)
+	}
+	return t.String(), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t TimeOfDay) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *TimeOfDay) UnmarshalText(text []byte) error {
+	v, err := ParseTimeOfDay(string(text))
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+// Randomize implements randomize.Randomizer, the times have the microsecond
+// precision of the database.
+func (t *TimeOfDay) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*t = TimeOfDay{}
+		return
+	}
+	*t = randomTimeOfDay(nextInt)
+}
+
+func randomTimeOfDay(nextInt func() int64) TimeOfDay {
+	return TimeOfDay{
+		Hour:       int(nextInt() % 24),
+		Minute:     int(nextInt() % 60),
+		Second:     int(nextInt() % 60),
+		Nanosecond: int(nextInt()%1000000) * 1000,
+	}
+}
+
+// NullTimeOfDay is a TimeOfDay that may be NULL.
+type NullTimeOfDay struct {
+	TimeOfDay TimeOfDay
+	Valid     bool
+}
+
+// NewNullTimeOfDay creates a new NullTimeOfDay.
+func NewNullTimeOfDay(t TimeOfDay, valid bool) NullTimeOfDay {
+	return NullTimeOfDay{TimeOfDay: t, Valid: valid}
+}
+
+// NullTimeOfDayFrom creates a new NullTimeOfDay that is never NULL.
+func NullTimeOfDayFrom(t TimeOfDay) NullTimeOfDay {
+	return NewNullTimeOfDay(t, true)
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *NullTimeOfDay) Scan(src interface{}) error {
+	if src == nil {
+		*n = NullTimeOfDay{}
+		return nil
+	}
+
+	n.Valid = true
+	return n.TimeOfDay.Scan(src)
+}
+
+// Value implements the driver.Valuer interface.
+func (n NullTimeOfDay) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.TimeOfDay.Value()
+}
+
+// MarshalJSON implements json.Marshaler, a NULL time is null.
+func (n NullTimeOfDay) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.TimeOfDay)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullTimeOfDay) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = NullTimeOfDay{}
+		return nil
+	}
+
+	n.Valid = true
+	return json.Unmarshal(data, &n.TimeOfDay)
+}
+
+// Randomize implements randomize.Randomizer.
+func (n *NullTimeOfDay) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*n = NullTimeOfDay{}
+		return
+	}
+	*n = NullTimeOfDayFrom(randomTimeOfDay(nextInt))
+}
+
+// TimeOfDayArray is a TIME[] value, a nil slice is NULL.
+type TimeOfDayArray []TimeOfDay
+
+// Scan implements the sql.Scanner interface.
+func (a *TimeOfDayArray) Scan(src interface{}) error {
+	var strs pq.StringArray
+	if err := strs.Scan(src); err != nil {
+		return errors.Wrap(err, "crdbtypes: cannot scan TimeOfDayArray")
+	}
+	if strs == nil {
+		*a = nil
+		return nil
+	}
+
+	times := make(TimeOfDayArray, len(strs))
+	for i, s := range strs {
+		if err := times[i].UnmarshalText([]byte(s)); err != nil {
+			return err
+		}
+	}
+	*a = times
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (a TimeOfDayArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+
+	strs := make(pq.StringArray, len(a))
+	for i, t := range a {
+		if !t.IsValid() {
+			return nil, errors.Errorf("crdbtypes: invalid TimeOfDay %s in TimeOfDayArray", t)
+		}
+		strs[i] = t.String()
+	}
+	return strs.Value()
+}
+
+// Randomize implements randomize.Randomizer.
+func (a *TimeOfDayArray) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*a = nil
+		return
+	}
+	*a = TimeOfDayArray{randomTimeOfDay(nextInt)}
+}
+
+// TimeOfDayTZ is a TIMETZ value, a time of the day with the offset from UTC
+// of its time zone, in seconds east of UTC.
+type TimeOfDayTZ struct {
+	TimeOfDay
+	Offset int
+}
+
+// TimeOfDayTZOf returns the time of the day of t with the offset of its
+// location at t.
+func TimeOfDayTZOf(t time.Time) TimeOfDayTZ {
+	_, offset := t.Zone()
+	return TimeOfDayTZ{TimeOfDay: TimeOfDayOf(t), Offset: offset}
+}
+
+// ParseTimeOfDayTZ parses a time of the day followed by an offset from UTC,
+// as in 15:04:05-07, 15:04:05.999999+05:30 or 15:04:05Z.
+func ParseTimeOfDayTZ(s string) (TimeOfDayTZ, error) {
+	i := strings.IndexAny(s, "+-Z")
+	if i < 0 {
+		return TimeOfDayTZ{}, errors.Errorf("crdbtypes: invalid time of day with time zone %q", s)
+	}
+
+	t, err := ParseTimeOfDay(s[:i])
+	if err != nil {
+		return TimeOfDayTZ{}, err
+	}
+	if s[i] == 'Z' {
+		if s[i:] != "Z" {
+			return TimeOfDayTZ{}, errors.Errorf("crdbtypes: invalid time of day with time zone %q", s)
+		}
+		return TimeOfDayTZ{TimeOfDay: t}, nil
+	}
+
+	parts := strings.Split(s[i+1:], ":")
+	if len(parts) > 3 {
+		return TimeOfDayTZ{}, errors.Errorf("crdbtypes: invalid time of day with time zone %q", s)
+	}
+	var offset int
+	for n, unit := range []int{3600, 60, 1} {
+		if n == len(parts) {
+			break
+		}
+		v, err := parseTimeField(parts[n])
+		if err != nil {
+			return TimeOfDayTZ{}, errors.Wrapf(err, "crdbtypes: invalid time of day with time zone %q", s)
+		}
+		offset += v * unit
+	}
+	if s[i] == '-' {
+		offset = -offset
+	}
+
+	tz := TimeOfDayTZ{TimeOfDay: t, Offset: offset}
+	if !tz.IsValid() {
+		return TimeOfDayTZ{}, errors.Errorf("crdbtypes: invalid time of day with time zone %q", s)
+	}
+	return tz, nil
+}
+
+// IsValid tells whether t is a time of the day with an offset of at most 15
+// hours, the range of TIMETZ.
+func (t TimeOfDayTZ) IsValid() bool {
+	return t.TimeOfDay.IsValid() && t.Offset >= -15*3600 && t.Offset <= 15*3600
+}
+
+// On returns the time t of the day d, in a fixed time zone of the offset of t.
+func (t TimeOfDayTZ) On(d Date) time.Time {
+	return t.TimeOfDay.On(d, time.FixedZone("", t.Offset))
+}
+
+// String returns the time of the day followed by the offset, as in
+// 15:04:05-07 or 15:04:05+05:30.
+func (t TimeOfDayTZ) String() string {
+	sign := '+'
+	offset := t.Offset
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+
+	s := fmt.Sprintf("%s%c%02d", t.TimeOfDay, sign, offset/3600)
+	if offset%3600 != 0 {
+		s += fmt.Sprintf(":%02d", offset/60%60)
+		if offset%60 != 0 {
+			s += fmt.Sprintf(":%02d", offset%60)
+		}
+	}
+	return s
+}
+
+// Scan implements the sql.Scanner interface.
+func (t *TimeOfDayTZ) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case time.Time:
+		*t = TimeOfDayTZOf(src)
+		return nil
+	case string:
+		return t.UnmarshalText([]byte(src))
+	case []byte:
+		return t.UnmarshalText(src)
+	}
+	return errors.Errorf("crdbtypes: cannot scan %T into TimeOfDayTZ", src)
+}
+
+// Value implements the driver.Valuer interface.
+func (t TimeOfDayTZ) Value() (driver.Value, error) {
+	if !t.IsValid() {
+		return nil, errors.Errorf("crdbtypes: invalid TimeOfDayTZ %s", t)
+	}
+	return t.String(), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t TimeOfDayTZ) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *TimeOfDayTZ) UnmarshalText(text []byte) error {
+	v, err := ParseTimeOfDayTZ(string(text))
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+// Randomize implements randomize.Randomizer, the offsets are whole quarters of
+// an hour between -12:00 and +14:00.
+func (t *TimeOfDayTZ) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*t = TimeOfDayTZ{}
+		return
+	}
+	*t = randomTimeOfDayTZ(nextInt)
+}
+
+func randomTimeOfDayTZ(nextInt func() int64) TimeOfDayTZ {
+	return TimeOfDayTZ{
+		TimeOfDay: randomTimeOfDay(nextInt),
+		Offset:    int(nextInt()%105-48) * 15 * 60,
+	}
+}
+
+// NullTimeOfDayTZ is a TimeOfDayTZ that may be NULL.
+type NullTimeOfDayTZ struct {
+	TimeOfDayTZ TimeOfDayTZ
+	Valid       bool
+}
+
+// NewNullTimeOfDayTZ creates a new NullTimeOfDayTZ.
+func NewNullTimeOfDayTZ(t TimeOfDayTZ, valid bool) NullTimeOfDayTZ {
+	return NullTimeOfDayTZ{TimeOfDayTZ: t, Valid: valid}
+}
+
+// NullTimeOfDayTZFrom creates a new NullTimeOfDayTZ that is never NULL.
+func NullTimeOfDayTZFrom(t TimeOfDayTZ) NullTimeOfDayTZ {
+	return NewNullTimeOfDayTZ(t, true)
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *NullTimeOfDayTZ) Scan(src interface{}) error {
+	if src == nil {
+		*n = NullTimeOfDayTZ{}
+		return nil
+	}
+
+	n.Valid = true
+	return n.TimeOfDayTZ.Scan(src)
+}
+
+// Value implements the driver.Valuer interface.
+func (n NullTimeOfDayTZ) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.TimeOfDayTZ.Value()
+}
+
+// MarshalJSON implements json.Marshaler, a NULL time is null.
+func (n NullTimeOfDayTZ) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.TimeOfDayTZ)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullTimeOfDayTZ) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = NullTimeOfDayTZ{}
+		return nil
+	}
+
+	n.Valid = true
+	return json.Unmarshal(data, &n.TimeOfDayTZ)
+}
+
+// Randomize implements randomize.Randomizer.
+func (n *NullTimeOfDayTZ) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*n = NullTimeOfDayTZ{}
+		return
+	}
+	*n = NullTimeOfDayTZFrom(randomTimeOfDayTZ(nextInt))
+}
+
+// TimeOfDayTZArray is a TIMETZ[] value, a nil slice is NULL.
+type TimeOfDayTZArray []TimeOfDayTZ
+
+// Scan implements the sql.Scanner interface.
+func (a *TimeOfDayTZArray) Scan(src interface{}) error {
+	var strs pq.StringArray
+	if err := strs.Scan(src); err != nil {
+		return errors.Wrap(err, "crdbtypes: cannot scan TimeOfDayTZArray")
+	}
+	if strs == nil {
+		*a = nil
+		return nil
+	}
+
+	times := make(TimeOfDayTZArray, len(strs))
+	for i, s := range strs {
+		if err := times[i].UnmarshalText([]byte(s)); err != nil {
+			return err
+		}
+	}
+	*a = times
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (a TimeOfDayTZArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+
+	strs := make(pq.StringArray, len(a))
+	for i, t := range a {
+		if !t.IsValid() {
+			return nil, errors.Errorf("crdbtypes: invalid TimeOfDayTZ %s in TimeOfDayTZArray", t)
+		}
+		strs[i] = t.String()
+	}
+	return strs.Value()
+}
+
+// Randomize implements randomize.Randomizer.
+func (a *TimeOfDayTZArray) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*a = nil
+		return
+	}
+	*a = TimeOfDayTZArray{randomTimeOfDayTZ(nextInt)}
+}
@@ -0,0 +1,178 @@
+package crdbtypes
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseTimeOfDay(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want TimeOfDay
+		out  string
+	}{
+		{"15:04", TimeOfDay{15, 4, 0, 0}, "15:04:00"},
+		{"15:04:05", TimeOfDay{15, 4, 5, 0}, "15:04:05"},
+		{"15:04:05.123456", TimeOfDay{15, 4, 5, 123456000}, "15:04:05.123456"},
+		{"00:00:00.5", TimeOfDay{0, 0, 0, 500000000}, "00:00:00.5"},
+		{"24:00:00", TimeOfDay{24, 0, 0, 0}, "24:00:00"},
+	}
+
+	for _, test := range tests {
+		got, err := ParseTimeOfDay(test.in)
+		if err != nil {
+			t.Errorf("%s: %v", test.in, err)
+			continue
+		}
+		if got != test.want || got.String() != test.out {
+			t.Errorf("%s: want %+v %s, got %+v %s", test.in, test.want, test.out, got, got)
+		}
+	}
+
+	for _, in := range []string{"", "15", "15:04:05:06", "25:00", "24:00:01", "15:60", "15:04:05.", "-1:00", "15:04:05.1234567890"} {
+		if _, err := ParseTimeOfDay(in); err == nil {
+			t.Errorf("%q: want an error", in)
+		}
+	}
+
+	if d := (TimeOfDay{1, 2, 3, 4}).Duration(); d != time.Hour+2*time.Minute+3*time.Second+4 {
+		t.Errorf("want 1h2m3.000000004s, got %s", d)
+	}
+}
+
+func TestParseTimeOfDayTZ(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want TimeOfDayTZ
+		out  string
+	}{
+		{"15:04:05-07", TimeOfDayTZ{TimeOfDay{15, 4, 5, 0}, -7 * 3600}, "15:04:05-07"},
+		{"15:04:05.25+05:30", TimeOfDayTZ{TimeOfDay{15, 4, 5, 250000000}, 5*3600 + 30*60}, "15:04:05.25+05:30"},
+		{"15:04:05+00:00:30", TimeOfDayTZ{TimeOfDay{15, 4, 5, 0}, 30}, "15:04:05+00:00:30"},
+		{"15:04:05Z", TimeOfDayTZ{TimeOfDay{15, 4, 5, 0}, 0}, "15:04:05+00"},
+	}
+
+	for _, test := range tests {
+		got, err := ParseTimeOfDayTZ(test.in)
+		if err != nil {
+			t.Errorf("%s: %v", test.in, err)
+			continue
+		}
+		if got != test.want || got.String() != test.out {
+			t.Errorf("%s: want %+v %s, got %+v %s", test.in, test.want, test.out, got, got)
+		}
+	}
+
+	for _, in := range []string{"", "15:04:05", "15:04:05+16", "15:04:05+", "15:04:05Z01"} {
+		if _, err := ParseTimeOfDayTZ(in); err == nil {
+			t.Errorf("%q: want an error", in)
+		}
+	}
+}
+
+func TestTimeOfDayScanValue(t *testing.T) {
+	t.Parallel()
+
+	// lib/pq reads TIME values as time.Time of the year 0
+	var tod TimeOfDay
+	if err := tod.Scan(time.Date(0, time.January, 1, 15, 4, 5, 123000, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := tod.Value(); err != nil || v != "15:04:05.000123" {
+		t.Errorf("want 15:04:05.000123, got %v %v", v, err)
+	}
+	if err := tod.Scan(time.Date(0, time.January, 2, 0, 0, 0, 0, time.UTC)); err != nil || tod != (TimeOfDay{Hour: 24}) {
+		t.Errorf("want 24:00:00, got %v %v", tod, err)
+	}
+	if _, err := (TimeOfDay{Hour: 25}).Value(); err == nil {
+		t.Error("want an error for 25:00:00")
+	}
+
+	var tz TimeOfDayTZ
+	if err := tz.Scan(time.Date(0, time.January, 1, 15, 4, 5, 0, time.FixedZone("", -7*3600))); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := tz.Value(); err != nil || v != "15:04:05-07" {
+		t.Errorf("want 15:04:05-07, got %v %v", v, err)
+	}
+	if got := tz.On(Date{2021, time.March, 1}); !got.Equal(time.Date(2021, time.March, 1, 22, 4, 5, 0, time.UTC)) {
+		t.Errorf("want 2021-03-01 22:04:05 UTC, got %v", got)
+	}
+
+	var n NullTimeOfDayTZ
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("want an invalid NullTimeOfDayTZ, got %+v %v", n, err)
+	}
+	if err := n.Scan([]byte("15:04:05+01")); err != nil || n != NullTimeOfDayTZFrom(TimeOfDayTZ{TimeOfDay{15, 4, 5, 0}, 3600}) {
+		t.Errorf("want 15:04:05+01, got %+v %v", n, err)
+	}
+
+	var a TimeOfDayArray
+	if err := a.Scan([]byte(`{15:04:05,24:00:00}`)); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := a.Value(); err != nil || v != `{"15:04:05","24:00:00"}` {
+		t.Errorf(`want {"15:04:05","24:00:00"}, got %v %v`, v, err)
+	}
+
+	var tza TimeOfDayTZArray
+	if err := tza.Scan([]byte(`{15:04:05-07,15:04:05+05:30}`)); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := tza.Value(); err != nil || v != `{"15:04:05-07","15:04:05+05:30"}` {
+		t.Errorf(`want {"15:04:05-07","15:04:05+05:30"}, got %v %v`, v, err)
+	}
+}
+
+func TestTimeOfDayJSON(t *testing.T) {
+	t.Parallel()
+
+	type row struct {
+		T  TimeOfDay       `json:"t"`
+		TZ TimeOfDayTZ     `json:"tz"`
+		N  NullTimeOfDay   `json:"n"`
+		NZ NullTimeOfDayTZ `json:"nz"`
+	}
+
+	b, err := json.Marshal(row{T: TimeOfDay{15, 4, 5, 0}, TZ: TimeOfDayTZ{TimeOfDay{9, 30, 0, 0}, 3600}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"t":"15:04:05","tz":"09:30:00+01","n":null,"nz":null}`; string(b) != want {
+		t.Errorf("want %s, got %s", want, b)
+	}
+
+	var r row
+	if err := json.Unmarshal([]byte(`{"t":"01:02:03","tz":"01:02:03-03","n":"24:00:00","nz":"00:00:00+00"}`), &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.T != (TimeOfDay{1, 2, 3, 0}) || r.TZ.Offset != -3*3600 || r.N != NullTimeOfDayFrom(TimeOfDay{Hour: 24}) || !r.NZ.Valid {
+		t.Errorf("got %+v", r)
+	}
+}
+
+func TestTimeOfDayRandomize(t *testing.T) {
+	t.Parallel()
+
+	seed := int64(0)
+	nextInt := func() int64 { seed++; return seed }
+
+	for i := 0; i < 200; i++ {
+		var tz TimeOfDayTZ
+		tz.Randomize(nextInt, "timetz", false)
+		if !tz.IsValid() || tz.Nanosecond%1000 != 0 || tz.Offset%(15*60) != 0 || tz.Offset < -12*3600 || tz.Offset > 14*3600 {
+			t.Fatalf("want a valid time with microseconds and a quarter hour offset, got %v", tz)
+		}
+	}
+
+	var n NullTimeOfDay
+	n.Randomize(nextInt, "time", true)
+	if n.Valid {
+		t.Error("want a NULL time")
+	}
+}
@@ -0,0 +1,99 @@
+package driver
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+// rgxAnnotation matches the go: annotations of a column comment. Tags are
+// a key and a quoted value, which may contain spaces, as in
+// go:tag=validate:"min=1 max=5".
+var rgxAnnotation = regexp.MustCompile(`go:(type|import)=(\S+)|go:tag=([A-Za-z_][A-Za-z0-9_]*:"(?:[^"\\` + "`" + `]|\\.)*")`)
+
+// rgxPackageName matches the last element of an import path that is the name
+// of its package, with the major version element of modules after it.
+var rgxPackageName = regexp.MustCompile(`(?:^|/)([A-Za-z_][A-Za-z0-9_]*)(?:/v[0-9]+)?$`)
+
+// parseAnnotations splits a column comment into its text and one line per
+// go:type, go:import and go:tag annotation, as in go:type=OrderMeta. A go:type
+// that names no package is qualified with the one of the go:import, so that
+// go:type=OrderMeta go:import=example.com/app/model is model.OrderMeta.
+func parseAnnotations(comment string) (string, []string, error) {
+	var annotations, imports []string
+	typeIndex := -1
+	for _, m := range rgxAnnotation.FindAllStringSubmatch(comment, -1) {
+		if m[3] != "" {
+			annotations = append(annotations, "go:tag="+m[3])
+			continue
+		}
+		switch m[1] {
+		case "type":
+			typeIndex = len(annotations)
+		case "import":
+			imports = append(imports, m[2])
+		}
+		annotations = append(annotations, fmt.Sprintf("go:%s=%s", m[1], m[2]))
+	}
+	if annotations == nil {
+		return comment, nil, nil
+	}
+
+	if typeIndex >= 0 && len(imports) > 0 {
+		typ, err := qualifyType(strings.TrimPrefix(annotations[typeIndex], "go:type="), imports)
+		if err != nil {
+			return "", nil, err
+		}
+		annotations[typeIndex] = "go:type=" + typ
+	}
+
+	// Lines that only held annotations are dropped, the others lose the
+	// spaces around their annotations.
+	var lines []string
+	for _, line := range strings.Split(comment, "\n") {
+		text := strings.Join(strings.Fields(rgxAnnotation.ReplaceAllString(line, "")), " ")
+		if text == "" && strings.TrimSpace(line) != "" {
+			continue
+		}
+		lines = append(lines, text)
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n")), annotations, nil
+}
+
+// qualifyType prefixes typ with the name of the package of the one import when
+// it names no package nor a predeclared type, keeping the pointer and slice
+// markers in front of it, as in []*model.Tag. Other types are left as they are.
+func qualifyType(typ string, imports []string) (string, error) {
+	name := strings.TrimLeft(typ, "*[]")
+	if !token.IsIdentifier(name) || types.Universe.Lookup(name) != nil {
+		return typ, nil
+	}
+
+	if len(imports) > 1 {
+		return "", errors.Errorf("go:type=%s names no package and there are several go:import, qualify it as in go:type=pkg.%s", typ, name)
+	}
+	m := rgxPackageName.FindStringSubmatch(imports[0])
+	if m == nil {
+		return "", errors.Errorf("go:type=%s names no package and the package name of go:import=%s can't be told from its path, qualify it as in go:type=pkg.%s", typ, imports[0], name)
+	}
+
+	return typ[:len(typ)-len(name)] + m[1] + "." + name, nil
+}
+
+// applyAnnotations replaces the Go type of c with the one of its go:type
+// annotation. go:import and go:tag annotations are left to the templates.
+func applyAnnotations(c drivers.Column) drivers.Column {
+	for _, line := range strings.Split(c.Comment, "\n") {
+		if strings.HasPrefix(line, "go:type=") {
+			c.Type = strings.TrimPrefix(line, "go:type=")
+		}
+	}
+
+	return c
+}
@@ -0,0 +1,90 @@
+package driver
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestParseAnnotations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		comment     string
+		text        string
+		annotations []string
+	}{
+		{"Order metadata.", "Order metadata.", nil},
+		{
+			"go:type=OrderMeta go:import=example.com/app/model",
+			"",
+			[]string{"go:type=model.OrderMeta", "go:import=example.com/app/model"},
+		},
+		{
+			"go:import=github.com/acme/tags/v2 go:type=[]*Tag",
+			"",
+			[]string{"go:import=github.com/acme/tags/v2", "go:type=[]*tags.Tag"},
+		},
+		{
+			"go:type=model.Meta go:import=example.com/app/model go:import=encoding/json",
+			"",
+			[]string{"go:type=model.Meta", "go:import=example.com/app/model", "go:import=encoding/json"},
+		},
+		{
+			"go:type=[]byte go:import=example.com/app/model",
+			"",
+			[]string{"go:type=[]byte", "go:import=example.com/app/model"},
+		},
+		{
+			"The contact address go:tag=validate:\"required,max=5 x\" of the order.",
+			"The contact address of the order.",
+			[]string{"go:tag=validate:\"required,max=5 x\""},
+		},
+		{
+			"First line.\ngo:type=model.ID\nLast line.",
+			"First line.\nLast line.",
+			[]string{"go:type=model.ID"},
+		},
+	}
+
+	for _, test := range tests {
+		text, annotations, err := parseAnnotations(test.comment)
+		if err != nil {
+			t.Errorf("%q: %s", test.comment, err)
+		}
+		if text != test.text || !reflect.DeepEqual(annotations, test.annotations) {
+			t.Errorf("%q: want %q %q, got %q %q", test.comment, test.text, test.annotations, text, annotations)
+		}
+	}
+
+	// The package of an unqualified type has to be told apart
+	for _, comment := range []string{
+		"go:type=Meta go:import=example.com/app/model go:import=encoding/json",
+		"go:type=Node go:import=gopkg.in/yaml.v3",
+	} {
+		if _, _, err := parseAnnotations(comment); err == nil {
+			t.Errorf("%q: want an error", comment)
+		}
+	}
+}
+
+func TestApplyAnnotations(t *testing.T) {
+	t.Parallel()
+
+	d := &CockroachDBDriver{}
+	comment := "Order metadata.\ngo:type=model.OrderMeta\ngo:import=example.com/app/model"
+	c := d.TranslateColumnType(drivers.Column{Name: "meta", DBType: "jsonb", Comment: comment})
+	if c.Type != "model.OrderMeta" {
+		t.Errorf("want type model.OrderMeta, got %s", c.Type)
+	}
+	// The go:import lines are read by the templates
+	if c.Comment != comment {
+		t.Errorf("want comment %q, got %q", comment, c.Comment)
+	}
+
+	c = d.TranslateColumnType(drivers.Column{Name: "meta", DBType: "jsonb", Nullable: true, Comment: "Order metadata."})
+	if c.Type != "null.JSON" {
+		t.Errorf("want type null.JSON, got %s", c.Type)
+	}
+}
@@ -0,0 +1,666 @@
+package driver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+// expirationColumn is the column row-level TTL computes the expiration of
+// rows in, for tables with ttl_expire_after.
+const expirationColumn = "crdb_internal_expiration"
+
+var (
+	rgxShardColumn     = regexp.MustCompile(`^crdb_internal_.+_shard_(\d+)$`)
+	rgxRegionalByRowAs = regexp.MustCompile(`^REGIONAL BY ROW AS "?([^"]+)"?$`)
+)
+
+type (
+	// catalog is a snapshot of everything the driver needs to know about the
+	// generated schemas. It is loaded with a handful of set-based queries so
+	// that introspecting a table doesn't cost a round trip per table.
+	catalog struct {
+		columns     map[tableKey][]catalogColumn
+		primaryKeys map[tableKey]*drivers.PrimaryKey
+		foreignKeys map[tableKey][]foreignKeyColumn
+		enums       []enumType
+		// shards are the hidden shard columns of hash-sharded indexes
+		shards map[tableKey][]shardColumn
+		// localities are the table localities of a multi-region database,
+		// as in GLOBAL or REGIONAL BY ROW
+		localities map[tableKey]string
+		// ttls are the row-level TTL settings of the tables that have one
+		ttls map[tableKey]rowLevelTTL
+		// indexes are all the indexes of the tables, the primary one included
+		indexes map[tableKey][]*index
+		// checks are the check constraints of the tables
+		checks map[tableKey][]check
+		// sequences are the sequences of the schemas
+		sequences []tableKey
+		// comments are the COMMENT ON TABLE of the tables that have one
+		comments map[tableKey]string
+	}
+	tableKey struct {
+		schema string
+		name   string
+	}
+	catalogColumn struct {
+		name         string
+		dataType     string
+		defaultValue *string
+		nullable     bool
+		unique       bool
+		// generated is a computed column, stored or virtual
+		generated bool
+		// identity is the identity generation, ALWAYS or BY DEFAULT, empty
+		// when the column isn't an identity column
+		identity string
+		// hidden columns aren't part of the generated models
+		hidden bool
+		// comment is the COMMENT ON COLUMN of the column
+		comment string
+	}
+	// shardColumn is the hidden column CockroachDB computes for an index
+	// created USING HASH, as in crdb_internal_id_shard_16.
+	shardColumn struct {
+		name    string
+		buckets int
+	}
+	// rowLevelTTL is the row-level TTL of a table, from its ttl_expire_after
+	// or ttl_expiration_expression storage parameter.
+	rowLevelTTL struct {
+		expireAfter          string
+		expirationExpression string
+	}
+	// foreignKeyColumn is one (column, foreign column) pair of a foreign key,
+	// columnCount tells how many pairs the whole constraint has.
+	foreignKeyColumn struct {
+		name          string
+		column        string
+		foreignSchema string
+		foreignTable  string
+		foreignColumn string
+		columnCount   int
+	}
+)
+
+// snapshot returns the catalog, loading it on first use for the schemas
+// tables are listed from.
+func (d *CockroachDBDriver) snapshot(schema string) (*catalog, error) {
+	if d.catalog != nil {
+		return d.catalog, nil
+	}
+
+	if d.caps == (capabilities{}) {
+		caps, err := d.detectCapabilities()
+		if err != nil {
+			return nil, errors.Wrap(err, "sqlboiler-crdb failed to detect database version")
+		}
+		d.caps = caps
+	}
+
+	cat, err := d.loadCatalog(d.searchSchemas(schema))
+	if err != nil {
+		return nil, errors.Wrap(err, "sqlboiler-crdb failed to load catalog")
+	}
+	d.catalog = cat
+
+	return cat, nil
+}
+
+func (d *CockroachDBDriver) loadCatalog(schemas []string) (*catalog, error) {
+	cat := &catalog{
+		columns:     make(map[tableKey][]catalogColumn),
+		primaryKeys: make(map[tableKey]*drivers.PrimaryKey),
+		foreignKeys: make(map[tableKey][]foreignKeyColumn),
+		shards:      make(map[tableKey][]shardColumn),
+		localities:  make(map[tableKey]string),
+		ttls:        make(map[tableKey]rowLevelTTL),
+		indexes:     make(map[tableKey][]*index),
+		checks:      make(map[tableKey][]check),
+		comments:    make(map[tableKey]string),
+	}
+
+	var err error
+	if err = d.loadColumns(cat, schemas); err != nil {
+		return nil, err
+	}
+	if err = d.loadComments(cat, schemas); err != nil {
+		return nil, err
+	}
+	if err = d.loadUniqueColumns(cat, schemas); err != nil {
+		return nil, err
+	}
+	if err = d.loadPrimaryKeys(cat, schemas); err != nil {
+		return nil, err
+	}
+	if err = d.loadIndexes(cat, schemas); err != nil {
+		return nil, err
+	}
+	if err = d.loadForeignKeys(cat, schemas); err != nil {
+		return nil, err
+	}
+	if err = d.loadChecks(cat, schemas); err != nil {
+		return nil, err
+	}
+	if err = d.loadSequences(cat, schemas); err != nil {
+		return nil, err
+	}
+	if err = d.loadLocalities(cat, schemas); err != nil {
+		return nil, err
+	}
+	if err = d.loadRowLevelTTLs(cat, schemas); err != nil {
+		return nil, err
+	}
+	if cat.enums, err = d.enumTypes(); err != nil {
+		return nil, err
+	}
+
+	return cat, nil
+}
+
+func (d *CockroachDBDriver) loadColumns(cat *catalog, schemas []string) error {
+	makeQuery := func(dataTypeCol, generatedCol, identityCol, hiddenCol string) string {
+		return fmt.Sprintf(`SELECT
+	c.table_schema,
+	c.table_name,
+	c.column_name,
+	%s AS data_type,
+	c.column_default,
+	c.is_nullable = 'YES' AS is_nullable,
+	%s AS is_generated,
+	%s AS identity_generation,
+	%s AS is_hidden
+FROM
+	information_schema.columns AS c
+WHERE
+	c.table_schema = ANY($1)
+ORDER BY
+	c.table_schema, c.table_name, c.ordinal_position ASC;`, dataTypeCol, generatedCol, identityCol, hiddenCol)
+	}
+
+	// Ref: https://github.com/cockroachdb/cockroach/pull/28945
+	dataTypeCol := "c.data_type"
+	if d.caps.crdbSQLType {
+		dataTypeCol = "c.crdb_sql_type"
+	}
+	generatedCol := "false"
+	if d.caps.computedColumns {
+		generatedCol = "coalesce(c.generation_expression, '') != ''"
+	}
+	identityCol := "''"
+	if d.caps.identityColumns {
+		identityCol = "CASE WHEN c.is_identity = 'YES' THEN c.identity_generation ELSE '' END"
+	}
+	hiddenCol := "false"
+	if d.caps.hiddenColumns {
+		hiddenCol = "c.is_hidden = 'YES'"
+	}
+
+	rows, err := d.conn.Query(makeQuery(dataTypeCol, generatedCol, identityCol, hiddenCol), pq.Array(schemas))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key tableKey
+		var col catalogColumn
+		if err := rows.Scan(&key.schema, &key.name, &col.name, &col.dataType, &col.defaultValue, &col.nullable, &col.generated, &col.identity, &col.hidden); err != nil {
+			return errors.Wrap(err, "unable to scan columns")
+		}
+		if shard, ok := parseShardColumn(col); ok {
+			cat.shards[key] = append(cat.shards[key], shard)
+		}
+		cat.columns[key] = append(cat.columns[key], col)
+	}
+
+	return rows.Err()
+}
+
+// parseShardColumn tells whether col is the shard column of a hash-sharded
+// index, and how many buckets the index has.
+func parseShardColumn(col catalogColumn) (shardColumn, bool) {
+	if !col.hidden || !col.generated {
+		return shardColumn{}, false
+	}
+
+	m := rgxShardColumn.FindStringSubmatch(col.name)
+	if m == nil {
+		return shardColumn{}, false
+	}
+	buckets, err := strconv.Atoi(m[1])
+	if err != nil {
+		return shardColumn{}, false
+	}
+
+	return shardColumn{name: col.name, buckets: buckets}, true
+}
+
+// loadComments reads the comments of the tables and their columns from
+// pg_description, where the sub-object of a table is 0 for the table itself
+// and the attnum of its column otherwise.
+func (d *CockroachDBDriver) loadComments(cat *catalog, schemas []string) error {
+	if !d.caps.comments {
+		return nil
+	}
+
+	query := `SELECT
+	pgn.nspname,
+	pgc.relname,
+	coalesce(pga.attname, ''),
+	pgd.description
+FROM
+	pg_description AS pgd
+	INNER JOIN pg_class AS pgc
+	ON pgd.objoid = pgc.oid
+	INNER JOIN pg_namespace AS pgn
+	ON pgc.relnamespace = pgn.oid
+	LEFT JOIN pg_attribute AS pga
+	ON
+		pgc.oid = pga.attrelid
+		AND pgd.objsubid = pga.attnum
+WHERE
+	pgn.nspname = ANY($1)
+	AND pgc.relkind IN ('r', 'v', 'm')
+	AND (pgd.objsubid = 0 OR pga.attname IS NOT NULL);`
+
+	rows, err := d.conn.Query(query, pq.Array(schemas))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key tableKey
+		var column, comment string
+		if err := rows.Scan(&key.schema, &key.name, &column, &comment); err != nil {
+			return errors.Wrap(err, "unable to scan comments")
+		}
+		comment = strings.Replace(comment, "\r\n", "\n", -1)
+
+		if column == "" {
+			cat.comments[key] = comment
+			continue
+		}
+		cols := cat.columns[key]
+		for i := range cols {
+			if cols[i].name == column {
+				cols[i].comment = comment
+			}
+		}
+	}
+
+	return rows.Err()
+}
+
+// loadUniqueColumns marks the columns that are by themselves a primary key or
+// unique constraint. Hidden columns don't count, so the visible column of a
+// hash-sharded key is still unique.
+func (d *CockroachDBDriver) loadUniqueColumns(cat *catalog, schemas []string) error {
+	var hiddenClause string
+	if d.caps.hiddenColumns {
+		hiddenClause = `AND c.is_hidden = 'NO'`
+	}
+
+	query := fmt.Sprintf(`SELECT
+	kcu.table_schema,
+	kcu.table_name,
+	max(kcu.column_name)
+FROM
+	information_schema.key_column_usage AS kcu
+	INNER JOIN information_schema.table_constraints AS tc
+	ON
+		kcu.constraint_schema = tc.constraint_schema
+		AND kcu.table_name = tc.table_name
+		AND kcu.constraint_name = tc.constraint_name
+	INNER JOIN information_schema.columns AS c
+	ON
+		kcu.table_schema = c.table_schema
+		AND kcu.table_name = c.table_name
+		AND kcu.column_name = c.column_name
+WHERE
+	kcu.table_schema = ANY($1)
+	AND tc.constraint_type IN ('PRIMARY KEY', 'UNIQUE') %s
+GROUP BY
+	kcu.table_schema, kcu.table_name, kcu.constraint_name
+HAVING
+	count(*) = 1;`, hiddenClause)
+
+	rows, err := d.conn.Query(query, pq.Array(schemas))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key tableKey
+		var column string
+		if err := rows.Scan(&key.schema, &key.name, &column); err != nil {
+			return errors.Wrap(err, "unable to scan unique columns")
+		}
+
+		cols := cat.columns[key]
+		for i := range cols {
+			if cols[i].name == column {
+				cols[i].unique = true
+			}
+		}
+	}
+
+	return rows.Err()
+}
+
+func (d *CockroachDBDriver) loadPrimaryKeys(cat *catalog, schemas []string) error {
+	query := `SELECT
+	tc.table_schema,
+	tc.table_name,
+	tc.constraint_name,
+	kcu.column_name
+FROM
+	information_schema.table_constraints AS tc
+	INNER JOIN information_schema.key_column_usage AS kcu
+	ON
+		tc.constraint_schema = kcu.constraint_schema
+		AND tc.table_name = kcu.table_name
+		AND tc.constraint_name = kcu.constraint_name
+WHERE
+	tc.table_schema = ANY($1)
+	AND tc.constraint_type = 'PRIMARY KEY'
+ORDER BY
+	tc.table_schema, tc.table_name, kcu.ordinal_position ASC;`
+
+	rows, err := d.conn.Query(query, pq.Array(schemas))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key tableKey
+		var name, column string
+		if err := rows.Scan(&key.schema, &key.name, &name, &column); err != nil {
+			return errors.Wrap(err, "unable to scan primary keys")
+		}
+
+		pkey, ok := cat.primaryKeys[key]
+		if !ok {
+			pkey = &drivers.PrimaryKey{Name: name}
+			cat.primaryKeys[key] = pkey
+		}
+		pkey.Columns = append(pkey.Columns, column)
+	}
+
+	return rows.Err()
+}
+
+// loadForeignKeys reads every foreign key column pair, resolved by position in
+// conkey/confkey so composite keys pair up in constraint order.
+func (d *CockroachDBDriver) loadForeignKeys(cat *catalog, schemas []string) error {
+	query := `SELECT
+    pgn.nspname AS source_schema,
+    pgc.relname AS source_table,
+    pgcon.conname,
+    pgasrc.attname AS source_column,
+    dstnsp.nspname AS dest_schema,
+    dstlookupname.relname AS dest_table,
+    pgadst.attname AS dest_column,
+    array_length(pgcon.conkey, 1) AS column_count
+FROM
+    pg_namespace AS pgn
+    INNER JOIN pg_class AS pgc
+    ON pgn.oid = pgc.relnamespace AND pgc.relkind = 'r'
+    INNER JOIN
+        (
+            SELECT
+                conname,
+                connamespace,
+                conrelid,
+                confrelid,
+                conkey,
+                confkey,
+                generate_subscripts(conkey, 1) AS position
+            FROM
+                pg_constraint
+            WHERE
+                contype = 'f'
+        )
+            AS pgcon
+    ON
+        pgn.oid = pgcon.connamespace
+        AND pgc.oid = pgcon.conrelid
+    INNER JOIN pg_class AS dstlookupname
+    ON pgcon.confrelid = dstlookupname.oid
+    INNER JOIN pg_namespace AS dstnsp
+    ON dstlookupname.relnamespace = dstnsp.oid
+    INNER JOIN pg_attribute AS pgasrc
+    ON
+        pgcon.conrelid = pgasrc.attrelid
+        AND pgasrc.attnum = pgcon.conkey[pgcon.position]
+    INNER JOIN pg_attribute AS pgadst
+    ON
+        pgcon.confrelid = pgadst.attrelid
+        AND pgadst.attnum = pgcon.confkey[pgcon.position]
+WHERE
+    pgn.nspname = ANY($1)
+ORDER BY
+    pgn.nspname, pgc.relname, pgcon.conname DESC, pgcon.position ASC;`
+
+	rows, err := d.conn.Query(query, pq.Array(schemas))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key tableKey
+		var fkey foreignKeyColumn
+		err = rows.Scan(&key.schema, &key.name, &fkey.name, &fkey.column, &fkey.foreignSchema, &fkey.foreignTable, &fkey.foreignColumn, &fkey.columnCount)
+		if err != nil {
+			return errors.Wrap(err, "unable to scan foreign keys")
+		}
+		cat.foreignKeys[key] = append(cat.foreignKeys[key], fkey)
+	}
+
+	return rows.Err()
+}
+
+// loadLocalities reads the locality of the tables of a multi-region database,
+// tables of other databases have none.
+func (d *CockroachDBDriver) loadLocalities(cat *catalog, schemas []string) error {
+	if !d.caps.multiRegion {
+		return nil
+	}
+
+	query := `SELECT
+	schema_name,
+	name,
+	locality
+FROM
+	crdb_internal.tables
+WHERE
+	database_name = current_database()
+	AND schema_name = ANY($1)
+	AND drop_time IS NULL
+	AND locality IS NOT NULL;`
+
+	rows, err := d.conn.Query(query, pq.Array(schemas))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key tableKey
+		var locality string
+		if err := rows.Scan(&key.schema, &key.name, &locality); err != nil {
+			return errors.Wrap(err, "unable to scan table localities")
+		}
+		cat.localities[key] = locality
+	}
+
+	return rows.Err()
+}
+
+// regionalByRowColumn returns the region column of a REGIONAL BY ROW
+// locality, and an empty string for other localities.
+func regionalByRowColumn(locality string) string {
+	if locality == "REGIONAL BY ROW" {
+		return "crdb_region"
+	}
+	if m := rgxRegionalByRowAs.FindStringSubmatch(locality); m != nil {
+		return m[1]
+	}
+
+	return ""
+}
+
+// isRegionColumn tells whether column holds the region of the rows of a
+// REGIONAL BY ROW table.
+func (cat *catalog) isRegionColumn(key tableKey, column string) bool {
+	return column != "" && regionalByRowColumn(cat.localities[key]) == column
+}
+
+// loadRowLevelTTLs reads the row-level TTL storage parameters of the tables.
+func (d *CockroachDBDriver) loadRowLevelTTLs(cat *catalog, schemas []string) error {
+	if !d.caps.rowLevelTTL {
+		return nil
+	}
+
+	query := `SELECT
+	pgn.nspname,
+	pgc.relname,
+	pgc.reloptions
+FROM
+	pg_class AS pgc
+	INNER JOIN pg_namespace AS pgn
+	ON pgc.relnamespace = pgn.oid
+WHERE
+	pgn.nspname = ANY($1)
+	AND pgc.relkind = 'r'
+	AND pgc.reloptions IS NOT NULL;`
+
+	rows, err := d.conn.Query(query, pq.Array(schemas))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key tableKey
+		var options []string
+		if err := rows.Scan(&key.schema, &key.name, pq.Array(&options)); err != nil {
+			return errors.Wrap(err, "unable to scan storage parameters")
+		}
+
+		var ttl rowLevelTTL
+		for _, option := range options {
+			name, value := parseStorageParam(option)
+			switch name {
+			case "ttl_expire_after":
+				ttl.expireAfter = value
+			case "ttl_expiration_expression":
+				if d.caps.ttlExpirationExpression {
+					ttl.expirationExpression = value
+				}
+			}
+		}
+		if ttl != (rowLevelTTL{}) {
+			cat.ttls[key] = ttl
+		}
+	}
+
+	return rows.Err()
+}
+
+// parseStorageParam splits a storage parameter as found in
+// pg_class.reloptions, like ttl_expire_after='1 day':::INTERVAL, into its name
+// and unquoted value.
+func parseStorageParam(option string) (string, string) {
+	i := strings.IndexByte(option, '=')
+	if i < 0 {
+		return option, ""
+	}
+
+	name, value := option[:i], option[i+1:]
+	if j := strings.LastIndex(value, ":::"); j >= 0 {
+		value = value[:j]
+	}
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		value = strings.Replace(value[1:len(value)-1], "''", "'", -1)
+	}
+
+	return name, value
+}
+
+// isExpirationColumn tells whether column is the crdb_internal_expiration
+// column row-level TTL adds to tables with ttl_expire_after.
+func (cat *catalog) isExpirationColumn(key tableKey, column string) bool {
+	return column == expirationColumn && cat.ttls[key].expireAfter != ""
+}
+
+// tableMetadata returns the comment lines of the first column that describe
+// the table, for the templates: a table-comment: line per line of its
+// comment (see commentLines), each index as an index: line with its name followed by an
+// index-def: line with its definition, each unique key but the primary one as
+// a unique: line with its name followed by a unique-column: line per column,
+// its row-level TTL as ttl: expire_after= and ttl: expiration_expression=
+// lines, and each sequence of its schema as a schema-sequence: line, as
+// sqlboiler passes no sequences to the templates.
+func (cat *catalog) tableMetadata(key tableKey) []string {
+	lines := commentLines("table-comment:", cat.comments[key])
+	for _, idx := range cat.indexes[key] {
+		lines = append(lines, "index: "+idx.name, "index-def: "+cat.indexDefinition(key, idx))
+	}
+	for _, idx := range cat.indexes[key] {
+		if idx.primary || !idx.uniqueKey() {
+			continue
+		}
+		lines = append(lines, "unique: "+idx.name)
+		for _, column := range idx.keyColumns() {
+			lines = append(lines, "unique-column: "+column)
+		}
+	}
+	if ttl := cat.ttls[key]; ttl.expireAfter != "" {
+		lines = append(lines, "ttl: expire_after="+ttl.expireAfter)
+	}
+	if ttl := cat.ttls[key]; ttl.expirationExpression != "" {
+		lines = append(lines, "ttl: expiration_expression="+strings.Join(strings.Fields(ttl.expirationExpression), " "))
+	}
+	for _, seq := range cat.sequences {
+		if seq.schema == key.schema {
+			lines = append(lines, "schema-sequence: "+seq.name)
+		}
+	}
+	return lines
+}
+
+// commentLines returns a line with prefix per line of a COMMENT ON text, so
+// that no text a user writes is taken for a line the driver adds.
+func commentLines(prefix, comment string) []string {
+	if comment == "" {
+		return nil
+	}
+
+	var lines []string
+	for _, line := range strings.Split(comment, "\n") {
+		lines = append(lines, strings.TrimSpace(prefix+" "+line))
+	}
+	return lines
+}
+
+// isHidden tells whether column is a hidden column of the table.
+func (cat *catalog) isHidden(key tableKey, column string) bool {
+	for _, c := range cat.columns[key] {
+		if c.name == column {
+			return c.hidden
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,162 @@
+package driver
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseShardColumn(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		col  catalogColumn
+		want shardColumn
+		ok   bool
+	}{
+		{catalogColumn{name: "crdb_internal_id_shard_16", hidden: true, generated: true}, shardColumn{"crdb_internal_id_shard_16", 16}, true},
+		{catalogColumn{name: "crdb_internal_tenant_id_created_at_shard_8", hidden: true, generated: true}, shardColumn{"crdb_internal_tenant_id_created_at_shard_8", 8}, true},
+		{catalogColumn{name: "crdb_internal_id_shard_16", generated: true}, shardColumn{}, false},
+		{catalogColumn{name: "rowid", hidden: true}, shardColumn{}, false},
+	}
+
+	for _, test := range tests {
+		got, ok := parseShardColumn(test.col)
+		if ok != test.ok || got != test.want {
+			t.Errorf("%s: want %v %t, got %v %t", test.col.name, test.want, test.ok, got, ok)
+		}
+	}
+}
+
+func TestRegionalByRowColumn(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"REGIONAL BY ROW":                     "crdb_region",
+		"REGIONAL BY ROW AS home_region":      "home_region",
+		`REGIONAL BY ROW AS "Home Region"`:    "Home Region",
+		"REGIONAL BY TABLE IN PRIMARY REGION": "",
+		`REGIONAL BY TABLE IN "us-east1"`:     "",
+		"GLOBAL":                              "",
+	}
+
+	for locality, want := range tests {
+		if got := regionalByRowColumn(locality); got != want {
+			t.Errorf("%s: want %q, got %q", locality, want, got)
+		}
+	}
+}
+
+func TestParseStorageParam(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in    string
+		name  string
+		value string
+	}{
+		{"ttl='on'", "ttl", "on"},
+		{"ttl_expire_after='1 day':::INTERVAL", "ttl_expire_after", "1 day"},
+		{"ttl_expiration_expression='(created_at + ''30 days'')'", "ttl_expiration_expression", "(created_at + '30 days')"},
+		{"fillfactor=100", "fillfactor", "100"},
+	}
+
+	for _, test := range tests {
+		name, value := parseStorageParam(test.in)
+		if name != test.name || value != test.value {
+			t.Errorf("%s: want %s %q, got %s %q", test.in, test.name, test.value, name, value)
+		}
+	}
+}
+
+func TestTableMetadata(t *testing.T) {
+	t.Parallel()
+
+	key := tableKey{"public", "sessions"}
+	cat := &catalog{
+		sequences: []tableKey{{"public", "session_number_seq"}, {"billing", "invoice_number_seq"}},
+		comments:  map[tableKey]string{key: "Logged in users.\n\nExpire after a day. "},
+		ttls: map[tableKey]rowLevelTTL{
+			key: {expireAfter: "1 day", expirationExpression: "(created_at +\n\tINTERVAL '30 days')"},
+		},
+		indexes: map[tableKey][]*index{key: {
+			{name: "sessions_pkey", primary: true, unique: true, columns: []indexColumn{{name: "id"}}},
+			{name: "sessions_org_id_token_key", unique: true, columns: []indexColumn{{name: "org_id"}, {name: "token"}}},
+		}},
+	}
+
+	want := []string{
+		"table-comment: Logged in users.", "table-comment:", "table-comment: Expire after a day.",
+		"index: sessions_pkey", "index-def: PRIMARY KEY (id)",
+		"index: sessions_org_id_token_key", "index-def: UNIQUE INDEX (org_id, token)",
+		"unique: sessions_org_id_token_key", "unique-column: org_id", "unique-column: token",
+		"ttl: expire_after=1 day", "ttl: expiration_expression=(created_at + INTERVAL '30 days')",
+		"schema-sequence: session_number_seq",
+	}
+	if got := cat.tableMetadata(key); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	// Tables without metadata of their own still list the sequences of
+	// their schema
+	if got, want := cat.tableMetadata(tableKey{"billing", "invoices"}), []string{"schema-sequence: invoice_number_seq"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	if got := cat.tableMetadata(tableKey{"other", "users"}); len(got) != 0 {
+		t.Errorf("want no metadata, got %q", got)
+	}
+}
+
+func TestCommentLines(t *testing.T) {
+	t.Parallel()
+
+	// Text that reads like a line the driver adds stays text
+	want := []string{"comment: The order status.", "comment:", "comment: sequence: order_seq", "comment: go:tag=json"}
+	if got := commentLines("comment:", "The order status.\n\nsequence: order_seq \ngo:tag=json"); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	if got := commentLines("comment:", ""); got != nil {
+		t.Errorf("want no lines, got %q", got)
+	}
+}
+
+func TestColumnsHidden(t *testing.T) {
+	t.Parallel()
+
+	key := tableKey{"public", "sessions"}
+	d := &CockroachDBDriver{catalog: &catalog{
+		columns: map[tableKey][]catalogColumn{key: {
+			{name: "id", dataType: "INT8"},
+			{name: "crdb_internal_id_shard_16", dataType: "INT8", hidden: true, generated: true},
+			{name: "crdb_region", dataType: "crdb_internal_region", hidden: true},
+			{name: "crdb_internal_expiration", dataType: "TIMESTAMPTZ", hidden: true},
+		}},
+		localities: map[tableKey]string{key: "REGIONAL BY ROW"},
+		ttls:       map[tableKey]rowLevelTTL{key: {expireAfter: "1 day"}},
+	}}
+
+	columns, err := d.Columns("public", "sessions", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The shard column stays hidden, the region and expiration columns are
+	// part of the models and have to be selected by name
+	var names, hidden []string
+	for _, c := range columns {
+		names = append(names, c.Name)
+		for _, line := range strings.Split(c.Comment, "\n") {
+			if line == "hidden: true" {
+				hidden = append(hidden, c.Name)
+			}
+		}
+	}
+	if want := []string{"id", "crdb_region", "crdb_internal_expiration"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("want columns %q, got %q", want, names)
+	}
+	if want := []string{"crdb_region", "crdb_internal_expiration"}; !reflect.DeepEqual(hidden, want) {
+		t.Errorf("want hidden columns %q, got %q", want, hidden)
+	}
+	if !columns[2].AutoGenerated {
+		t.Error("want crdb_internal_expiration generated")
+	}
+}
@@ -0,0 +1,293 @@
+package driver
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/strmangle"
+)
+
+var (
+	rgxTypeAnnotation  = regexp.MustCompile(`:::[A-Za-z][A-Za-z0-9_ ]*(\(\d+(,\s*\d+)?\))?(\[\])?`)
+	rgxCheckComparison = regexp.MustCompile(`^("[^"]+"|\w+) (>=|<=|<>|!=|=|>|<) (-?\d+(\.\d+)?)$`)
+	rgxCheckLength     = regexp.MustCompile(`^(?:length|char_length|character_length)\(("[^"]+"|\w+)\) (>=|<=|<>|!=|=|>|<) (\d+)$`)
+	rgxCheckNotNull    = regexp.MustCompile(`^("[^"]+"|\w+) IS NOT NULL$`)
+	rgxCheckIn         = regexp.MustCompile(`^("[^"]+"|\w+) IN \((.+)\)$`)
+	rgxCheckInValue    = regexp.MustCompile(`^'((?:[^']|'')+)'$`)
+)
+
+// checkOperators maps the comparison operators of SQL to the ones of Go.
+var checkOperators = map[string]string{
+	"=":  "==",
+	"<>": "!=",
+}
+
+type (
+	// check is a CHECK constraint of a table, expression is its definition
+	// without type annotations, as in CHECK (amount > 0).
+	check struct {
+		name       string
+		expression string
+		columns    []string
+	}
+	// checkRules is what the generated Validate method can verify of a
+	// single-column check constraint.
+	checkRules struct {
+		// conditions compare the column or its length with a constant, as in
+		// "amount > 0", list its allowed strings, as in
+		// "status IN ('new','paid')", or are "column IS NOT NULL"
+		conditions []string
+	}
+)
+
+// loadChecks reads the check constraints of the tables with the columns they
+// reference.
+func (d *CockroachDBDriver) loadChecks(cat *catalog, schemas []string) error {
+	if !d.caps.checks {
+		return nil
+	}
+
+	query := `SELECT
+	pgn.nspname,
+	pgc.relname,
+	pgcon.conname,
+	pg_get_constraintdef(pgcon.oid),
+	pga.attname
+FROM
+	pg_constraint AS pgcon
+	INNER JOIN pg_class AS pgc
+	ON pgcon.conrelid = pgc.oid
+	INNER JOIN pg_namespace AS pgn
+	ON pgc.relnamespace = pgn.oid
+	INNER JOIN pg_attribute AS pga
+	ON
+		pgcon.conrelid = pga.attrelid
+		AND pga.attnum = ANY(pgcon.conkey)
+WHERE
+	pgn.nspname = ANY($1)
+	AND pgcon.contype = 'c'
+ORDER BY
+	pgn.nspname, pgc.relname, pgcon.conname, pga.attnum ASC;`
+
+	rows, err := d.conn.Query(query, pq.Array(schemas))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key tableKey
+		var name, def, column string
+		if err := rows.Scan(&key.schema, &key.name, &name, &def, &column); err != nil {
+			return errors.Wrap(err, "unable to scan check constraints")
+		}
+
+		checks := cat.checks[key]
+		if last := len(checks) - 1; last >= 0 && checks[last].name == name {
+			checks[last].columns = append(checks[last].columns, column)
+			continue
+		}
+		cat.checks[key] = append(checks, check{
+			name:       name,
+			expression: rgxTypeAnnotation.ReplaceAllString(def, ""),
+			columns:    []string{column},
+		})
+	}
+
+	return rows.Err()
+}
+
+// String returns the constraint as in a CREATE TABLE statement.
+func (c check) String() string {
+	return fmt.Sprintf("CONSTRAINT %s %s", c.name, c.expression)
+}
+
+// parseCheck translates the expression of a check constraint on column, a
+// column of type dbType, into checkRules. It returns false when part of the
+// expression can't be verified in Go, the whole constraint is then left to
+// the database.
+func parseCheck(expression, column, dbType string) (checkRules, bool) {
+	var rules checkRules
+
+	expression = strings.TrimPrefix(expression, "CHECK ")
+	for _, term := range splitTopLevel(trimParens(expression), " AND ") {
+		term = trimParens(term)
+
+		if m := rgxCheckNotNull.FindStringSubmatch(term); m != nil && unquoteIdent(m[1]) == column {
+			rules.conditions = append(rules.conditions, column+" IS NOT NULL")
+			continue
+		}
+
+		if m := rgxCheckComparison.FindStringSubmatch(term); m != nil && unquoteIdent(m[1]) == column {
+			isFloat := m[4] != ""
+			if !isIntType(dbType) && !isFloatType(dbType) && !isDecimalType(dbType) || isFloat && isIntType(dbType) {
+				return checkRules{}, false
+			}
+			rules.conditions = append(rules.conditions, fmt.Sprintf("%s %s %s", column, goOperator(m[2]), m[3]))
+			continue
+		}
+
+		if m := rgxCheckLength.FindStringSubmatch(term); m != nil && unquoteIdent(m[1]) == column {
+			if !isTextType(dbType) {
+				return checkRules{}, false
+			}
+			rules.conditions = append(rules.conditions, fmt.Sprintf("length(%s) %s %s", column, goOperator(m[2]), m[3]))
+			continue
+		}
+
+		if m := rgxCheckIn.FindStringSubmatch(term); m != nil && unquoteIdent(m[1]) == column {
+			if !isTextType(dbType) {
+				return checkRules{}, false
+			}
+			// The values are listed like the labels of an enum DBType, for
+			// parseEnumVals, which can't tell a quote in a value apart
+			var values []string
+			for _, item := range splitTopLevel(m[2], ", ") {
+				v := rgxCheckInValue.FindStringSubmatch(item)
+				if v == nil || strings.Contains(v[1], "''") {
+					return checkRules{}, false
+				}
+				values = append(values, v[1])
+			}
+			rules.conditions = append(rules.conditions, fmt.Sprintf("%s IN ('%s')", column, strings.Join(values, "','")))
+			continue
+		}
+
+		return checkRules{}, false
+	}
+
+	return rules, true
+}
+
+// trimParens removes the parentheses that enclose the whole of s.
+func trimParens(s string) string {
+	s = strings.TrimSpace(s)
+	for len(s) >= 2 && s[0] == '(' && closingParen(s) == len(s)-1 {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	return s
+}
+
+// closingParen returns the index of the parenthesis closing the one s starts
+// with, or -1. Parentheses within string literals don't count.
+func closingParen(s string) int {
+	depth := 0
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\'':
+			quoted = !quoted
+		case quoted:
+		case s[i] == '(':
+			depth++
+		case s[i] == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitTopLevel splits s on the occurrences of sep that aren't nested in
+// parentheses or string literals.
+func splitTopLevel(s, sep string) []string {
+	var terms []string
+	depth := 0
+	quoted := false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\'':
+			quoted = !quoted
+		case quoted:
+		case s[i] == '(':
+			depth++
+		case s[i] == ')':
+			depth--
+		case depth == 0 && strings.HasPrefix(s[i:], sep):
+			terms = append(terms, s[start:i])
+			start = i + len(sep)
+			i = start - 1
+		}
+	}
+	return append(terms, s[start:])
+}
+
+func unquoteIdent(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+func goOperator(op string) string {
+	if goOp, ok := checkOperators[op]; ok {
+		return goOp
+	}
+	return op
+}
+
+func isIntType(dbType string) bool {
+	switch dbType {
+	case "int8", "bigint", "bigserial", "int4", "int", "integer", "serial", "int2", "smallint", "smallserial":
+		return true
+	}
+	return false
+}
+
+func isFloatType(dbType string) bool {
+	switch dbType {
+	case "float8", "float", "double precision", "real":
+		return true
+	}
+	return false
+}
+
+func isDecimalType(dbType string) bool {
+	switch dbType {
+	case "decimal", "numeric", "dec":
+		return true
+	}
+	return false
+}
+
+func isTextType(dbType string) bool {
+	switch dbType {
+	case "string", "text", "character", "character varying", "char", "varchar", "collate":
+		return true
+	}
+	return false
+}
+
+// columnChecks returns the comment lines describing the check constraints on
+// column, a column of type dbType. Lines of the checks the Validate template
+// verifies start with "check: ", the others with "unchecked: ".
+func (cat *catalog) columnChecks(key tableKey, column, dbType string) []string {
+	var lines []string
+
+	for _, c := range cat.checks[key] {
+		if !strmangle.SetInclude(column, c.columns) {
+			continue
+		}
+
+		rules, ok := checkRules{}, false
+		if len(c.columns) == 1 {
+			rules, ok = parseCheck(c.expression, column, dbType)
+		}
+		if !ok {
+			lines = append(lines, "unchecked: "+c.String())
+			continue
+		}
+
+		for _, cond := range rules.conditions {
+			lines = append(lines, "check: "+cond)
+		}
+	}
+
+	return lines
+}
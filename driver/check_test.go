@@ -0,0 +1,69 @@
+package driver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		expression string
+		column     string
+		dbType     string
+		want       checkRules
+		ok         bool
+	}{
+		{"CHECK ((amount > 0))", "amount", "int8", checkRules{conditions: []string{"amount > 0"}}, true},
+		{"CHECK ((amount >= 0) AND (amount <> 7))", "amount", "int8", checkRules{conditions: []string{"amount >= 0", "amount != 7"}}, true},
+		{"CHECK ((price > 0.5))", "price", "float8", checkRules{conditions: []string{"price > 0.5"}}, true},
+		{"CHECK ((price > 0.5))", "price", "int8", checkRules{}, false},
+		{"CHECK ((price > 0.5))", "price", "decimal", checkRules{conditions: []string{"price > 0.5"}}, true},
+		{"CHECK ((total >= 0))", "total", "numeric", checkRules{conditions: []string{"total >= 0"}}, true},
+		{"CHECK ((length(\"Name\") <= 50))", "Name", "string", checkRules{conditions: []string{"length(Name) <= 50"}}, true},
+		{"CHECK ((note IS NOT NULL))", "note", "jsonb", checkRules{conditions: []string{"note IS NOT NULL"}}, true},
+		{"CHECK ((status IN ('new', 'paid', 'done, (ok)')))", "status", "string", checkRules{conditions: []string{"status IN ('new','paid','done, (ok)')"}}, true},
+		{"CHECK ((status IN ('new', 'it''s')))", "status", "string", checkRules{}, false},
+		{"CHECK ((status IN ('new', 'paid')))", "status", "int8", checkRules{}, false},
+		{"CHECK ((prio IN (1, 2)))", "prio", "int8", checkRules{}, false},
+		{"CHECK ((amount BETWEEN 1 AND 10))", "amount", "int8", checkRules{}, false},
+		{"CHECK (((amount > 0) OR (amount < -10)))", "amount", "int8", checkRules{}, false},
+		{"CHECK ((other > 0))", "amount", "int8", checkRules{}, false},
+	}
+
+	for _, test := range tests {
+		got, ok := parseCheck(test.expression, test.column, test.dbType)
+		if ok != test.ok || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s on %s: want %+v %t, got %+v %t", test.expression, test.dbType, test.want, test.ok, got, ok)
+		}
+	}
+}
+
+func TestColumnChecks(t *testing.T) {
+	t.Parallel()
+
+	key := tableKey{"public", "orders"}
+	cat := &catalog{checks: map[tableKey][]check{
+		key: {
+			{name: "check_amount", expression: "CHECK ((amount > 0))", columns: []string{"amount"}},
+			{name: "check_dates", expression: "CHECK ((shipped_at > created_at))", columns: []string{"created_at", "shipped_at"}},
+			{name: "check_status", expression: "CHECK ((status IN ('new', 'paid')))", columns: []string{"status"}},
+		},
+	}}
+
+	lines := cat.columnChecks(key, "amount", "int8")
+	if !reflect.DeepEqual(lines, []string{"check: amount > 0"}) {
+		t.Errorf("amount: got %q", lines)
+	}
+
+	lines = cat.columnChecks(key, "shipped_at", "timestamptz")
+	if !reflect.DeepEqual(lines, []string{"unchecked: CONSTRAINT check_dates CHECK ((shipped_at > created_at))"}) {
+		t.Errorf("shipped_at: got %q", lines)
+	}
+
+	lines = cat.columnChecks(key, "status", "string")
+	if !reflect.DeepEqual(lines, []string{"check: status IN ('new','paid')"}) {
+		t.Errorf("status: got %q", lines)
+	}
+}
@@ -8,14 +8,12 @@ import (
 	"io/fs"
 	"os"
 	"regexp"
-	"strconv"
 	"strings"
 
-	_ "github.com/lib/pq" // Side-effect import sql driver
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"github.com/volatiletech/sqlboiler/v4/drivers"
 	"github.com/volatiletech/sqlboiler/v4/importers"
-	"github.com/volatiletech/sqlboiler/v4/types"
 	"github.com/volatiletech/strmangle"
 )
 
@@ -24,6 +22,36 @@ var templates embed.FS
 
 var re = regexp.MustCompile(`\(([^\)]+)\)`)
 
+// configSchemas is the driver config key listing several schemas to generate
+// models for in one run, the first one being the default schema.
+const configSchemas = "schemas"
+
+// regionEnum is the enum type CockroachDB creates with the regions of a
+// multi-region database.
+const regionEnum = "crdb_internal_region"
+
+// configRowIDPrimaryKey is the driver config key that surfaces the hidden
+// rowid of tables created without a primary key as their primary key.
+const configRowIDPrimaryKey = "rowid-primary-key"
+
+// configInetNetip is the driver config key that maps INET columns to the
+// net/netip backed crdbtypes.Inet instead of strings.
+const configInetNetip = "inet-netip"
+
+// configCivilTime is the driver config key that maps DATE, TIME and TIMETZ
+// columns to the crdbtypes civil types instead of time.Time.
+const configCivilTime = "civil-time"
+
+// configUUID is the driver config key naming the package UUID columns are
+// mapped to, gofrs, google or an import path like github.com/gofrs/uuid/v5.
+const configUUID = "uuid"
+
+// uuidPackages are the import paths of the short names of configUUID.
+var uuidPackages = map[string]string{
+	"gofrs":  "github.com/gofrs/uuid",
+	"google": "github.com/google/uuid",
+}
+
 // Assemble is more useful for calling into the library so you don't
 // have to instantiate an empty type.
 func Assemble(config drivers.Config) (dbinfo *drivers.DBInfo, err error) {
@@ -39,8 +67,24 @@ type (
 		conn           *sql.DB
 		addEnumTypes   bool
 		enumNullPrefix string
+		rowIDPKey      bool
+		inetNetip      bool
+		civilTime      bool
+		// uuidPackage is the import path of the package of the UUID types,
+		// UUID columns are strings when it's empty
+		uuidPackage string
+
+		// schemas and tableSchemas are only set when generating several
+		// schemas at once, tables are then looked up in the schema they
+		// were listed from.
+		schemas      []string
+		tableSchemas map[string]string
+
+		caps    capabilities
+		catalog *catalog
 	}
 	enumType struct {
+		schema string
 		name   string
 		values []string
 	}
@@ -91,10 +135,29 @@ func (d *CockroachDBDriver) Assemble(config drivers.Config) (dbinfo *drivers.DBI
 	schema := config.DefaultString(drivers.ConfigSchema, "public")
 	whitelist, _ := config.StringSlice(drivers.ConfigWhitelist)
 	blacklist, _ := config.StringSlice(drivers.ConfigBlacklist)
-	useSchema := schema != "public"
+	schemas, _ := config.StringSlice(configSchemas)
+	if len(schemas) > 0 {
+		// Tables of several schemas can't be qualified with a single schema,
+		// they are left unqualified and resolved through the search_path.
+		schema = schemas[0]
+		d.schemas = schemas
+		d.tableSchemas = make(map[string]string)
+	}
+	useSchema := schema != "public" && len(schemas) == 0
 
 	d.addEnumTypes, _ = config[drivers.ConfigAddEnumTypes].(bool)
 	d.enumNullPrefix = strmangle.TitleCase(config.DefaultString(drivers.ConfigEnumNullPrefix, "Null"))
+	d.rowIDPKey, _ = config[configRowIDPrimaryKey].(bool)
+	d.inetNetip, _ = config[configInetNetip].(bool)
+	d.civilTime, _ = config[configCivilTime].(bool)
+	if pkg := config.DefaultString(configUUID, ""); pkg != "" {
+		if path, ok := uuidPackages[pkg]; ok {
+			pkg = path
+		} else if !strings.Contains(pkg, "/") {
+			return nil, errors.Errorf("sqlboiler-crdb: unknown uuid package %q, use gofrs, google or an import path", pkg)
+		}
+		d.uuidPackage = pkg
+	}
 	d.connStr = buildQueryString(user, pass, dbname, host, port, sslmode)
 	d.conn, err = sql.Open("postgres", d.connStr)
 	if err != nil {
@@ -108,6 +171,16 @@ func (d *CockroachDBDriver) Assemble(config drivers.Config) (dbinfo *drivers.DBI
 		}
 	}()
 
+	d.caps, err = d.detectCapabilities()
+	if err != nil {
+		return nil, errors.Wrap(err, "sqlboiler-crdb failed to detect database version")
+	}
+
+	d.catalog, err = d.loadCatalog(d.searchSchemas(schema))
+	if err != nil {
+		return nil, errors.Wrap(err, "sqlboiler-crdb failed to load catalog")
+	}
+
 	dbinfo = &drivers.DBInfo{
 		Schema: schema,
 		Dialect: drivers.Dialect{
@@ -125,6 +198,12 @@ func (d *CockroachDBDriver) Assemble(config drivers.Config) (dbinfo *drivers.DBI
 		return nil, err
 	}
 
+	if len(d.schemas) > 0 {
+		for i := range dbinfo.Tables {
+			dbinfo.Tables[i].SchemaName = d.tableSchema(schema, dbinfo.Tables[i].Name)
+		}
+	}
+
 	return dbinfo, err
 }
 
@@ -132,10 +211,8 @@ func (d *CockroachDBDriver) Assemble(config drivers.Config) (dbinfo *drivers.DBI
 // retrieves all table names from the information_schema where the
 // table schema is schema. It uses a whitelist and blacklist.
 func (d *CockroachDBDriver) TableNames(schema string, whitelist, blacklist []string) ([]string, error) {
-	var names []string
-
-	query := fmt.Sprintf(`SELECT table_name FROM information_schema.tables WHERE table_schema = $1 AND table_type = 'BASE TABLE'`)
-	args := []interface{}{schema}
+	query := fmt.Sprintf(`SELECT table_schema, table_name FROM information_schema.tables WHERE table_schema = ANY($1) AND table_type = 'BASE TABLE'`)
+	args := []interface{}{pq.Array(d.searchSchemas(schema))}
 	if len(whitelist) > 0 {
 		tables := drivers.TablesFromList(whitelist)
 		if len(tables) > 0 {
@@ -161,483 +238,277 @@ func (d *CockroachDBDriver) TableNames(schema string, whitelist, blacklist []str
 	}
 
 	defer rows.Close()
+	var keys []tableKey
 	for rows.Next() {
-		var name string
-		if err := rows.Scan(&name); err != nil {
+		var key tableKey
+		if err := rows.Scan(&key.schema, &key.name); err != nil {
 			return nil, err
 		}
-		names = append(names, name)
+		keys = append(keys, key)
 	}
 
-	return names, nil
+	return d.addTableSchemas(keys)
 }
 
 // Columns takes a table name and attempts to retrieve the table information
-// from the database information_schema.columns. It retrieves the column names
-// and column types and returns those as a []Column after TranslateColumnType()
-// converts the SQL types to Go types, for example: "varchar" to "string"
+// from the catalog snapshot of information_schema.columns. It retrieves the
+// column names and column types and returns those as a []Column after
+// TranslateColumnType() converts the SQL types to Go types, for example:
+// "varchar" to "string"
 func (d *CockroachDBDriver) Columns(schema, tableName string, whitelist, blacklist []string) ([]drivers.Column, error) {
-	makeQuery := func(dataTypeCol, whereClause string) string {
-		return fmt.Sprintf(`SELECT
-		DISTINCT
-		c.column_name,
-		c.ordinal_position,
-		max(%s) AS data_type,
-		max(c.column_default) AS column_default,
-		bool_or(
-			CASE
-			WHEN c.is_nullable = 'NO' THEN false
-			ELSE true
-			END
-		)
-			AS is_nullable,
-		bool_or(
-			CASE
-			WHEN pc.count < 2 AND pgc.contype IN ('p', 'u')
-			THEN true
-			ELSE false
-			END
-		)
-			AS is_unique
-	FROM
-		information_schema.columns AS c
-		LEFT JOIN
-			(
-				SELECT
-					DISTINCT
-					c.column_name,
-					pgc.conname AS conname,
-					pgc.contype AS contype
-				FROM
-					information_schema.columns AS c
-					LEFT JOIN
-						information_schema.key_column_usage
-							AS kcu
-					ON
-						c.table_name = kcu.table_name
-						AND c.table_schema = kcu.table_schema
-						AND c.column_name = kcu.column_name
-					LEFT JOIN pg_constraint AS pgc
-					ON kcu.constraint_name = pgc.conname
-				WHERE
-					c.table_schema = $1 AND c.table_name = $2
-			)
-				AS pgc
-		ON c.column_name = pgc.column_name
-		LEFT JOIN
-			(
-				SELECT
-					kcu.table_schema,
-					kcu.table_name,
-					kcu.constraint_name,
-					count(*)
-				FROM
-					information_schema.key_column_usage AS kcu
-				GROUP BY
-					kcu.table_schema,
-					kcu.table_name,
-					kcu.constraint_name
-			)
-				AS pc
-		ON
-			c.table_schema = pc.table_schema
-			AND c.table_name = pc.table_name
-			AND pgc.conname = pc.constraint_name
-	WHERE
-		c.table_schema = $1 AND c.table_name = $2 %s
-	GROUP BY
-		c.ordinal_position, c.column_name
-	ORDER BY
-		c.ordinal_position ASC;`, dataTypeCol, whereClause)
+	cat, err := d.snapshot(schema)
+	if err != nil {
+		return nil, err
 	}
 
 	var columns []drivers.Column
-	args := []interface{}{schema, tableName}
 
-	var whereClause string
+	var filter func(string) bool
 	if len(whitelist) > 0 {
-		cols := drivers.ColumnsFromList(whitelist, tableName)
-		if len(cols) > 0 {
-			whereClause += fmt.Sprintf(" AND c.column_name IN (%s)", strmangle.Placeholders(true, len(cols), 3, 1))
-			for _, w := range cols {
-				args = append(args, w)
-			}
+		if cols := drivers.ColumnsFromList(whitelist, tableName); len(cols) > 0 {
+			filter = func(name string) bool { return strmangle.SetInclude(name, cols) }
 		}
 	} else if len(blacklist) > 0 {
-		cols := drivers.ColumnsFromList(blacklist, tableName)
-		if len(cols) > 0 {
-			whereClause += fmt.Sprintf(" AND c.column_name NOT IN (%s)", strmangle.Placeholders(true, len(cols), 3, 1))
-			for _, w := range cols {
-				args = append(args, w)
-			}
+		if cols := drivers.ColumnsFromList(blacklist, tableName); len(cols) > 0 {
+			filter = func(name string) bool { return !strmangle.SetInclude(name, cols) }
 		}
 	}
 
-	rows, err := d.conn.Query(makeQuery("c.crdb_sql_type", whereClause+` AND c.is_hidden = 'NO'`), args...)
-	if err != nil {
-		// TODO(g.lerchundi): Remove this fallback logic post-2.2.
-		// Ref: https://github.com/cockroachdb/cockroach/pull/28945
-		if strings.Contains(err.Error(), "column \"crdb_sql_type\" does not exist") {
-			rows, err = d.conn.Query(makeQuery("c.data_type", whereClause+` AND c.is_hidden = 'NO'`), args...)
-		}
-		if err != nil && strings.Contains(err.Error(), "column \"is_hidden\" does not exist") {
-			rows, err = d.conn.Query(makeQuery("c.data_type", whereClause), args...)
+	key := tableKey{d.tableSchema(schema, tableName), tableName}
+	for _, c := range cat.columns[key] {
+		exposed := d.isRowIDPrimaryKey(cat, key, c.name) || cat.isRegionColumn(key, c.name) || cat.isExpirationColumn(key, c.name)
+		if (c.hidden && !exposed) || (filter != nil && !filter(c.name)) {
+			continue
 		}
-	}
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	enums, err := d.enumTypes(schema)
-	if err != nil {
-		return nil, err
-	}
 
-	for rows.Next() {
-		var colName, colType, udtName string
-		var ordinalPos int32
-		var defaultValue, arrayType *string
-		var nullable, unique bool
-		if err := rows.Scan(&colName, &ordinalPos, &colType, &defaultValue, &nullable, &unique); err != nil {
-			return nil, errors.Wrapf(err, "unable to scan for table %s", tableName)
-		}
+		var udtName string
+		var arrayType *string
+		defaultValue := c.defaultValue
 
 		// To prevent marking nullable columns as not having a default value
 		// Techinically, every nullable column is "DEFAULT NULL"
-		if nullable && defaultValue == nil {
+		if c.nullable && defaultValue == nil {
 			null := "NULL"
 			defaultValue = &null
 		}
 
 		// TODO(glerchundi): find a better way to infer this.
-		dbType := strings.ToLower(re.ReplaceAllString(colType, ""))
+		dbType := strings.ToLower(re.ReplaceAllString(c.dataType, ""))
+		fullDBType := typeModifiers(dbType, c.dataType)
 		tmp := strings.Replace(dbType, "[]", "", 1)
+		// Enums list the labels the DBType can't in enum-label lines, the
+		// ones of arrays of enums included.
+		var labels []string
 		if dbType != tmp {
+			// Arrays of enums keep the enum values for the randomizer
+			labels = cat.enumLabelLines(schema, tmp)
+			tmp = cat.enumDBType(schema, tmp)
 			arrayType = &tmp
 			dbType = "array"
+		} else {
+			labels = cat.enumLabelLines(schema, dbType)
 		}
 
-		// Check if type is an ENUM
-		if enums != nil && strings.Contains(dbType, ".") {
-			parts := strings.Split(dbType, ".")
-			if len(parts) == 2 && parts[0] == schema {
-				for _, enum := range enums {
-					if enum.name == parts[1] {
-						dbType = enum.String()
-					}
-				}
-			}
+		// Check if type is an ENUM, which may live in another schema
+		dbType = cat.enumDBType(schema, dbType)
+
+		// Check constraints are listed in the column comment, after the
+		// comment of the column itself, for the Validate template.
+		checks := cat.columnChecks(key, c.name, dbType)
+
+		// go:type, go:import and go:tag annotations get a line each, for
+		// TranslateColumnType and the struct template, and the rest of the
+		// comment a comment: line per line.
+		text, annotations, err := parseAnnotations(c.comment)
+		if err != nil {
+			return nil, errors.Wrapf(err, "column %s.%s", tableName, c.name)
+		}
+		comment := commentLines("comment:", text)
+		comment = append(comment, checks...)
+		comment = append(comment, labels...)
+		// The sequence the default takes its values from gets helpers in
+		// the crdb_sequences singleton.
+		if seq, ok := cat.columnSequence(key, c.defaultValue); ok {
+			comment = append(comment, "sequence: "+seq)
 		}
+		// The bucket count of a hash-sharded index is a constant of the
+		// column_limits template.
+		if buckets := cat.shardBuckets(key, c.name); buckets > 0 {
+			comment = append(comment, fmt.Sprintf("shard-buckets: %d", buckets))
+		}
+		// SELECT * leaves hidden columns out, the queries of the table list
+		// them after it.
+		if c.hidden {
+			comment = append(comment, "hidden: true")
+		}
+		// The templates only see the columns, the metadata of the table
+		// itself goes on the first one.
+		if len(columns) == 0 {
+			comment = append(comment, cat.tableMetadata(key)...)
+		}
+		comment = append(comment, annotations...)
 
 		column := drivers.Column{
-			Name:     colName,
-			DBType:   dbType,
-			ArrType:  arrayType,
-			UDTName:  udtName,
-			Nullable: nullable,
-			Unique:   unique,
+			Name:          c.name,
+			DBType:        dbType,
+			FullDBType:    fullDBType,
+			ArrType:       arrayType,
+			UDTName:       udtName,
+			Nullable:      c.nullable,
+			Unique:        c.unique,
+			AutoGenerated: c.generated || c.identity == "ALWAYS",
+			Comment:       strings.Join(comment, "\n"),
 		}
 		if defaultValue != nil {
 			column.Default = *defaultValue
 		}
 
-		columns = append(columns, column)
-	}
-
-	return columns, nil
-}
-
-func (d *CockroachDBDriver) enumTypes(schema string) ([]enumType, error) {
-	var enums []enumType
-
-	rows, err := d.conn.Query("SHOW ENUMS")
-	if err != nil {
-		if strings.Contains(err.Error(), `unrecognized configuration parameter "enums"`) {
-			return enums, nil // if CockroachDB < v20.2 - return empty slice
+		if c.identity != "" {
+			column.Default = "IDENTITY"
 		}
-		return nil, err
-	}
-	defer rows.Close()
 
-	for rows.Next() {
-		columns, err := rows.Columns()
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to return enums table column names")
+		// The expiration of a row is maintained by the database, on insert and
+		// on update, so it's read back rather than written.
+		if cat.isExpirationColumn(key, c.name) {
+			column.AutoGenerated = true
 		}
 
-		var enumSchema, enumName, enumOwner string
-		var enumValues sql.NullString
-		switch len(columns) {
-		case 4: // >= v20.2.2 (has owner column)
-			if err := rows.Scan(&enumSchema, &enumName, &enumValues, &enumOwner); err != nil {
-				return nil, errors.Wrap(err, "failed to scan enum types")
-			}
-		case 3: // < v20.2.2
-			if err := rows.Scan(&enumSchema, &enumName, &enumValues); err != nil {
-				return nil, errors.Wrap(err, "failed to scan enum types")
-			}
-		default:
-			return nil, errors.New("unexpected number of columns in enums table: " + strconv.Itoa(len(columns)))
-		}
-		if schema == enumSchema {
-			eType := enumType{
-				name: enumName,
-			}
-			if enumValues.Valid {
-				if enumValues.String[0] == '{' && enumValues.String[len(enumValues.String)-1:] == "}" {
-					// >= v21.1.0 - "{a,b,c}"
-					var values types.StringArray
-					if err := values.Scan(enumValues.String); err != nil {
-						return nil, errors.New(fmt.Sprintf("failed to scan enum values to array: %v", enumValues.String))
-					}
-					eType.values = values
-				} else {
-					// < v21.1.0 - "a|b|c"
-					eType.values = strings.Split(enumValues.String, "|") // will not split properly if enum values contain | characters
-				}
-			} else { // enum type created without values
-				eType.values = nil
-			}
-			enums = append(enums, eType)
+		// A generated column technically has a default value
+		if column.AutoGenerated && (column.Default == "" || column.Default == "NULL") {
+			column.Default = "GENERATED"
 		}
+
+		columns = append(columns, column)
 	}
 
-	return enums, nil
+	return columns, nil
 }
 
 // PrimaryKeyInfo looks up the primary key for a table.
 func (d *CockroachDBDriver) PrimaryKeyInfo(schema, tableName string) (*drivers.PrimaryKey, error) {
-	pkey := &drivers.PrimaryKey{}
-	var err error
-
-	query := `SELECT
-    tc.constraint_name
-FROM
-    information_schema.table_constraints AS tc
-WHERE
-    tc.table_name = $1
-    AND tc.constraint_type = 'PRIMARY KEY'
-    AND tc.table_schema = $2;`
-
-	row := d.conn.QueryRow(query, tableName, schema)
-	if err = row.Scan(&pkey.Name); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
+	cat, err := d.snapshot(schema)
+	if err != nil {
 		return nil, err
 	}
 
-	queryColumns := `SELECT
-    kcu.column_name
-FROM
-    information_schema.key_column_usage AS kcu
-WHERE
-    constraint_name = $1
-    AND table_schema = $2
-    AND table_name = $3;`
-
-	var rows *sql.Rows
-	if rows, err = d.conn.Query(queryColumns, pkey.Name, schema, tableName); err != nil {
-		return nil, err
+	key := tableKey{d.tableSchema(schema, tableName), tableName}
+	pkey, ok := cat.primaryKeys[key]
+	if !ok {
+		return nil, nil
 	}
 
-	defer rows.Close()
-
+	// Hidden columns, like the shard column of a hash-sharded primary key,
+	// aren't in the model so they can't be part of its primary key.
 	var columns []string
-	for rows.Next() {
-		var column string
-
-		err = rows.Scan(&column)
-		if err != nil {
-			return nil, err
+	for _, column := range pkey.Columns {
+		if !cat.isHidden(key, column) || d.isRowIDPrimaryKey(cat, key, column) {
+			columns = append(columns, column)
 		}
-
-		columns = append(columns, column)
 	}
-
-	if err = rows.Err(); err != nil {
-		return nil, err
+	if len(columns) == 0 {
+		return nil, nil
 	}
 
-	pkey.Columns = columns
+	return &drivers.PrimaryKey{
+		Name:    pkey.Name,
+		Columns: columns,
+	}, nil
+}
+
+// isRowIDPrimaryKey tells whether column is the implicit rowid primary key
+// CockroachDB adds to tables created without one, and it has to be exposed.
+func (d *CockroachDBDriver) isRowIDPrimaryKey(cat *catalog, key tableKey, column string) bool {
+	if !d.rowIDPKey || column != "rowid" {
+		return false
+	}
 
-	return pkey, nil
+	pkey, ok := cat.primaryKeys[key]
+	return ok && len(pkey.Columns) == 1 && pkey.Columns[0] == column && cat.isHidden(key, column)
 }
 
 // ForeignKeyInfo retrieves the foreign keys for a given table name.
+//
+// sqlboiler can only express single column relationships between tables of
+// the generated schemas, so composite foreign keys and foreign keys into other
+// schemas are reported on stderr and skipped.
 func (d *CockroachDBDriver) ForeignKeyInfo(schema, tableName string) ([]drivers.ForeignKey, error) {
-	var fkeys []drivers.ForeignKey
-
-	query := `SELECT
-    DISTINCT
-    pgcon.conname,
-    pgc.relname AS source_table,
-    kcu.column_name AS source_column,
-    dstlookupname.relname AS dest_table,
-    pgadst.attname AS dest_column
-FROM
-    pg_namespace AS pgn
-    INNER JOIN pg_class AS pgc
-    ON pgn.oid = pgc.relnamespace AND pgc.relkind = 'r'
-    INNER JOIN pg_constraint AS pgcon
-    ON
-        pgn.oid = pgcon.connamespace
-        AND pgc.oid = pgcon.conrelid
-    INNER JOIN pg_class AS dstlookupname
-    ON pgcon.confrelid = dstlookupname.oid
-    LEFT JOIN information_schema.key_column_usage AS kcu
-    ON
-        pgcon.conname = kcu.constraint_name
-        AND pgc.relname = kcu.table_name
-    LEFT JOIN information_schema.key_column_usage AS kcudst
-    ON
-        pgcon.conname = kcu.constraint_name
-        AND dstlookupname.relname = kcu.table_name
-    INNER JOIN pg_attribute AS pgadst
-    ON
-        pgcon.confrelid = pgadst.attrelid
-        AND pgadst.attnum = ANY pgcon.confkey
-WHERE
-    pgn.nspname = $2
-    AND pgc.relname = $1
-    AND pgcon.contype = 'f'
-ORDER BY
-    pgcon.conname DESC;`
-
-	var rows *sql.Rows
-	var err error
-	if rows, err = d.conn.Query(query, tableName, schema); err != nil {
+	cat, err := d.snapshot(schema)
+	if err != nil {
 		return nil, err
 	}
 
-	for rows.Next() {
-		var fkey drivers.ForeignKey
-		var sourceTable string
+	var fkeys []drivers.ForeignKey
 
-		fkey.Table = tableName
-		err = rows.Scan(&fkey.Name, &sourceTable, &fkey.Column, &fkey.ForeignTable, &fkey.ForeignColumn)
-		if err != nil {
-			return nil, err
+	generated := d.searchSchemas(schema)
+	schema = d.tableSchema(schema, tableName)
+
+	var composite []drivers.ForeignKey
+	crossSchema := make(map[string]bool)
+	for _, c := range cat.foreignKeys[tableKey{schema, tableName}] {
+		fkey := drivers.ForeignKey{
+			Table:         tableName,
+			Name:          c.name,
+			Column:        c.column,
+			ForeignTable:  c.foreignTable,
+			ForeignColumn: c.foreignColumn,
+		}
+
+		if !strmangle.SetInclude(c.foreignSchema, generated) {
+			if !crossSchema[fkey.Name] {
+				crossSchema[fkey.Name] = true
+				fmt.Fprintf(os.Stderr, "Warning: skipping foreign key %s on %s.%s referencing %s.%s, schema %s is not being generated\n",
+					fkey.Name, schema, tableName, c.foreignSchema, fkey.ForeignTable, c.foreignSchema)
+			}
+			continue
+		}
+		// The model of the foreign table name has to be the one of the
+		// referenced table, not a table of the same name in another schema.
+		if other := d.tableSchema(c.foreignSchema, c.foreignTable); other != c.foreignSchema {
+			if !crossSchema[fkey.Name] {
+				crossSchema[fkey.Name] = true
+				fmt.Fprintf(os.Stderr, "Warning: skipping foreign key %s on %s.%s referencing %s.%s, the model %s is the table of schema %s\n",
+					fkey.Name, schema, tableName, c.foreignSchema, fkey.ForeignTable, fkey.ForeignTable, other)
+			}
+			continue
+		}
+
+		if c.columnCount > 1 {
+			composite = append(composite, fkey)
+			continue
 		}
 
 		fkeys = append(fkeys, fkey)
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, err
-	}
+	warnCompositeForeignKeys(composite)
 
 	return fkeys, nil
 }
 
-// TranslateColumnType converts Cockroach database types to Go types, for example
-// "varchar" to "string" and "bigint" to "int64". It returns this parsed data
-// as a Column object.
-func (d *CockroachDBDriver) TranslateColumnType(c drivers.Column) drivers.Column {
-	// parse DB type
-	if c.Nullable {
-		switch c.DBType {
-		case "int8", "bigint", "bigserial":
-			c.Type = "null.Int64"
-		case "int4", "int", "integer", "serial":
-			c.Type = "null.Int"
-		case "int2", "smallint", "smallserial":
-			c.Type = "null.Int16"
-		case "decimal", "numeric":
-			c.Type = "types.NullDecimal"
-		case "float8", "float", "double precision":
-			c.Type = "null.Float64"
-		case "real":
-			c.Type = "null.Float32"
-		case "string", "collate", "bit", "interval", "bit varying", "character", "character varying", "char", "varchar", "inet", "uuid", "text":
-			c.Type = "null.String"
-		case `"char"`:
-			c.Type = "null.Byte"
-		case "bytes", "bytea":
-			c.Type = "null.Bytes"
-		case "json", "jsonb":
-			c.Type = "null.JSON"
-		case "bool", "boolean":
-			c.Type = "null.Bool"
-		case "date", "time", "timestamp", "timestamp without time zone", "timestamptz", "timestamp with time zone":
-			c.Type = "null.Time"
-		case "array", "ARRAY":
-			if c.ArrType == nil {
-				panic("unable to get CockroachDB ARRAY underlying type")
-			}
-			c.Type = getArrayType(c)
-			// Make DBType something like ARRAYinteger for parsing with randomize.Struct
-			c.DBType = strings.ToUpper(c.DBType) + *c.ArrType
-		default:
-			if enumName := strmangle.ParseEnumName(c.DBType); enumName != "" {
-				if d.addEnumTypes {
-					c.Type = d.enumNullPrefix + strmangle.TitleCase(enumName)
-				} else {
-					c.Type = "null.String"
-				}
-			} else {
-				fmt.Fprintf(os.Stderr, "Warning: Unhandled nullable data type %s, falling back to null.String\n", c.DBType)
-				c.Type = "null.String"
-			}
-		}
-	} else {
-		switch c.DBType {
-		case "int8", "bigint", "bigserial":
-			c.Type = "int64"
-		case "int4", "int", "integer", "serial":
-			c.Type = "int"
-		case "int2", "smallint", "smallserial":
-			c.Type = "int16"
-		case "decimal", "numeric":
-			c.Type = "types.Decimal"
-		case "float8", "float", "double precision":
-			c.Type = "float64"
-		case "real":
-			c.Type = "float32"
-		case "string", "collate", "bit", "interval", "bit varying", "character", "character varying", "char", "varchar", "inet", "uuid", "text":
-			c.Type = "string"
-		case `"char"`:
-			c.Type = "types.Byte"
-		case "bytes", "bytea":
-			c.Type = "[]byte"
-		case "json", "jsonb":
-			c.Type = "types.JSON"
-		case "bool", "boolean":
-			c.Type = "bool"
-		case "date", "time", "timestamp", "timestamp without time zone", "timestamptz", "timestamp with time zone":
-			c.Type = "time.Time"
-		case "array", "ARRAY":
-			if c.ArrType == nil {
-				panic("unable to get CockroachDB ARRAY underlying type")
-			}
-			c.Type = getArrayType(c)
-			// Make DBType something like ARRAYinteger for parsing with randomize.Struct
-			c.DBType = strings.ToUpper(c.DBType) + *c.ArrType
-		default:
-			if enumName := strmangle.ParseEnumName(c.DBType); enumName != "" {
-				if d.addEnumTypes {
-					c.Type = strmangle.TitleCase(enumName)
-				} else {
-					c.Type = "string"
-				}
-			} else {
-				fmt.Fprintf(os.Stderr, "Warning: Unhandled data type %s, falling back to string\n", c.DBType)
-				c.Type = "string"
-			}
+// warnCompositeForeignKeys prints one warning per composite foreign key,
+// listing its column pairs in constraint order. The pairs must be grouped by
+// constraint name, as returned by ForeignKeyInfo's query.
+func warnCompositeForeignKeys(pairs []drivers.ForeignKey) {
+	for i := 0; i < len(pairs); {
+		j := i
+		var local, foreign []string
+		for ; j < len(pairs) && pairs[j].Name == pairs[i].Name; j++ {
+			local = append(local, pairs[j].Column)
+			foreign = append(foreign, pairs[j].ForeignColumn)
 		}
+
+		fmt.Fprintf(os.Stderr, "Warning: skipping composite foreign key %s on %s (%s) referencing %s (%s), multi-column relationships are not supported\n",
+			pairs[i].Name, pairs[i].Table, strings.Join(local, ", "), pairs[i].ForeignTable, strings.Join(foreign, ", "))
+		i = j
 	}
-	return c
 }
 
 // ViewNames connects to the postgres database and
 // retrieves all view names from the information_schema where the
 // view schema is schema. It uses a whitelist and blacklist.
 func (d *CockroachDBDriver) ViewNames(schema string, whitelist, blacklist []string) ([]string, error) {
-	var names []string
-
-	query := `SELECT table_name FROM information_schema.views WHERE table_schema = $1`
-	args := []interface{}{schema}
+	query := `SELECT table_schema, table_name FROM information_schema.views WHERE table_schema = ANY($1)`
+	args := []interface{}{pq.Array(d.searchSchemas(schema))}
 	if len(whitelist) > 0 {
 		views := drivers.TablesFromList(whitelist)
 		if len(views) > 0 {
@@ -665,16 +536,16 @@ func (d *CockroachDBDriver) ViewNames(schema string, whitelist, blacklist []stri
 	}
 
 	defer rows.Close()
+	var keys []tableKey
 	for rows.Next() {
-		var name string
-		if err := rows.Scan(&name); err != nil {
+		var key tableKey
+		if err := rows.Scan(&key.schema, &key.name); err != nil {
 			return nil, err
 		}
-
-		names = append(names, name)
+		keys = append(keys, key)
 	}
 
-	return names, nil
+	return d.addTableSchemas(keys)
 }
 
 // ViewCapabilities return what actions are allowed for a view.
@@ -690,7 +561,7 @@ func (d *CockroachDBDriver) ViewCapabilities(schema, name string) (drivers.ViewC
 	from information_schema.views where table_schema = $1 and table_name = $2
 	order by table_name;`
 
-	row := d.conn.QueryRow(query, schema, name)
+	row := d.conn.QueryRow(query, d.tableSchema(schema, name), name)
 
 	var insertable, updatable, trInsert, trUpdate, trDelete bool
 	if err := row.Scan(&insertable, &updatable, &trInsert, &trUpdate, &trDelete); err != nil {
@@ -707,27 +578,6 @@ func (d *CockroachDBDriver) ViewColumns(schema, tableName string, whitelist, bla
 	return d.Columns(schema, tableName, whitelist, blacklist)
 }
 
-// getArrayType returns the correct boil.Array type for each database type
-func getArrayType(c drivers.Column) string {
-	switch *c.ArrType {
-	case "int2", "int4", "int8", "int", "integer", "serial", "smallint", "smallserial", "bigint", "bigserial":
-		return "types.Int64Array"
-	case "bytes", "bytea":
-		return "types.BytesArray"
-	case "string", "collate", "bit", "interval", "bit varying", "character", "character varying", "char", "varchar", "inet", "text", "uuid":
-		return "types.StringArray"
-	case "bool", "boolean":
-		return "types.BoolArray"
-	case "decimal", "numeric":
-		return "types.DecimalArray"
-	case "float8", "float", "double precision", "real":
-		return "types.Float64Array"
-	default:
-		fmt.Fprintf(os.Stderr, "Warning: Unhandled array data type %s, falling back to types.StringArray\n", *c.ArrType)
-		return "types.StringArray"
-	}
-}
-
 // Imports for the CockroachDB driver
 func (d *CockroachDBDriver) Imports() (importers.Collection, error) {
 	var col importers.Collection
@@ -738,6 +588,24 @@ func (d *CockroachDBDriver) Imports() (importers.Collection, error) {
 		},
 	}
 	col.Singleton = importers.Map{
+		"crdb_enum_slices": {
+			Standard: importers.List{
+				`"database/sql/driver"`,
+			},
+			ThirdParty: importers.List{
+				`"github.com/volatiletech/sqlboiler/v4/types"`,
+			},
+		},
+		"crdb_sequences": {
+			Standard: importers.List{
+				`"fmt"`,
+			},
+			ThirdParty: importers.List{
+				`"github.com/friendsofgo/errors"`,
+				`"github.com/volatiletech/sqlboiler/v4/boil"`,
+				`"github.com/volatiletech/strmangle"`,
+			},
+		},
 		"crdb_upsert": {
 			Standard: importers.List{
 				`"fmt"`,
@@ -770,7 +638,7 @@ func (d *CockroachDBDriver) Imports() (importers.Collection, error) {
 				`"github.com/pkg/errors"`,
 				`"github.com/spf13/viper"`,
 				`"github.com/volatiletech/randomize"`,
-				`_ "github.com/lib/pq"`,
+				`"github.com/lib/pq"`,
 			},
 		},
 	}
@@ -856,6 +724,64 @@ func (d *CockroachDBDriver) Imports() (importers.Collection, error) {
 		"types.NullDecimal": {
 			ThirdParty: importers.List{`"github.com/volatiletech/sqlboiler/v4/types"`},
 		},
+		"crdbtypes.Interval": {
+			ThirdParty: importers.List{`"github.com/dgollings/sqlboiler-crdb/v4/crdbtypes"`},
+		},
+		"crdbtypes.NullInterval": {
+			ThirdParty: importers.List{`"github.com/dgollings/sqlboiler-crdb/v4/crdbtypes"`},
+		},
+		"crdbtypes.IntervalArray": {
+			ThirdParty: importers.List{`"github.com/dgollings/sqlboiler-crdb/v4/crdbtypes"`},
+		},
+		"crdbtypes.Inet": {
+			ThirdParty: importers.List{`"github.com/dgollings/sqlboiler-crdb/v4/crdbtypes"`},
+		},
+		"crdbtypes.NullInet": {
+			ThirdParty: importers.List{`"github.com/dgollings/sqlboiler-crdb/v4/crdbtypes"`},
+		},
+		"crdbtypes.InetArray": {
+			ThirdParty: importers.List{`"github.com/dgollings/sqlboiler-crdb/v4/crdbtypes"`},
+		},
+		"crdbtypes.Geometry": {
+			ThirdParty: importers.List{`"github.com/dgollings/sqlboiler-crdb/v4/crdbtypes"`},
+		},
+		"crdbtypes.NullGeometry": {
+			ThirdParty: importers.List{`"github.com/dgollings/sqlboiler-crdb/v4/crdbtypes"`},
+		},
+		"crdbtypes.Date": {
+			ThirdParty: importers.List{`"github.com/dgollings/sqlboiler-crdb/v4/crdbtypes"`},
+		},
+		"crdbtypes.NullDate": {
+			ThirdParty: importers.List{`"github.com/dgollings/sqlboiler-crdb/v4/crdbtypes"`},
+		},
+		"crdbtypes.DateArray": {
+			ThirdParty: importers.List{`"github.com/dgollings/sqlboiler-crdb/v4/crdbtypes"`},
+		},
+		"crdbtypes.TimeOfDay": {
+			ThirdParty: importers.List{`"github.com/dgollings/sqlboiler-crdb/v4/crdbtypes"`},
+		},
+		"crdbtypes.NullTimeOfDay": {
+			ThirdParty: importers.List{`"github.com/dgollings/sqlboiler-crdb/v4/crdbtypes"`},
+		},
+		"crdbtypes.TimeOfDayArray": {
+			ThirdParty: importers.List{`"github.com/dgollings/sqlboiler-crdb/v4/crdbtypes"`},
+		},
+		"crdbtypes.TimeOfDayTZ": {
+			ThirdParty: importers.List{`"github.com/dgollings/sqlboiler-crdb/v4/crdbtypes"`},
+		},
+		"crdbtypes.NullTimeOfDayTZ": {
+			ThirdParty: importers.List{`"github.com/dgollings/sqlboiler-crdb/v4/crdbtypes"`},
+		},
+		"crdbtypes.TimeOfDayTZArray": {
+			ThirdParty: importers.List{`"github.com/dgollings/sqlboiler-crdb/v4/crdbtypes"`},
+		},
+		// The UUID types are declared in the models package by the crdb_uuid
+		// singleton, the model files don't import anything for them. The
+		// package they wrap depends on the uuid config, which Imports()
+		// doesn't get, so the singleton imports it itself.
+		"CRDBUUID":      {},
+		"CRDBNullUUID":  {},
+		"CRDBUUIDArray": {},
 	}
 
 	return col, nil
@@ -872,8 +798,3 @@ func buildQueryString(user, pass, dbname, host string, port int, sslmode string)
 
 	return fmt.Sprintf("postgresql://%s@%s:%d/%s?sslmode=%s", up, host, port, dbname, sslmode)
 }
-
-func (e enumType) String() string {
-	// format understandable to drivers.FilterColumnsByEnum, strmangle.ParseEnumName and strmangle.ParseEnumVals
-	return fmt.Sprintf("enum.%s('%s')", e.name, strings.Join(e.values, "','"))
-}
@@ -0,0 +1,181 @@
+package driver
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// enumTypes returns the enum types of every schema in the database, with
+// their labels in sort order.
+func (d *CockroachDBDriver) enumTypes() ([]enumType, error) {
+	var enums []enumType
+
+	if !d.caps.enums {
+		return enums, nil
+	}
+
+	query := `SELECT
+	pgn.nspname,
+	pgt.typname,
+	pge.enumlabel
+FROM
+	pg_type AS pgt
+	INNER JOIN pg_namespace AS pgn
+	ON pgt.typnamespace = pgn.oid
+	LEFT JOIN pg_enum AS pge
+	ON pgt.oid = pge.enumtypid
+WHERE
+	pgt.typtype = 'e'
+ORDER BY
+	pgn.nspname, pgt.typname, pge.enumsortorder ASC;`
+
+	rows, err := d.conn.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var enumSchema, enumName string
+		var enumLabel sql.NullString
+		if err := rows.Scan(&enumSchema, &enumName, &enumLabel); err != nil {
+			return nil, errors.Wrap(err, "failed to scan enum types")
+		}
+
+		last := len(enums) - 1
+		if last < 0 || enums[last].schema != enumSchema || enums[last].name != enumName {
+			enums = append(enums, enumType{
+				schema: enumSchema,
+				name:   enumName,
+			})
+			last++
+		}
+		if enumLabel.Valid { // enum type created without values has no labels
+			if !isEnumLabelListable(enumLabel.String) {
+				fmt.Fprintf(os.Stderr, "Warning: enum %s.%s label %q can't be listed in its DBType, its columns are strings and it isn't randomized\n", enumSchema, enumName, enumLabel.String)
+			}
+			enums[last].values = append(enums[last].values, enumLabel.String)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return enums, nil
+}
+
+// enumDBType returns the enum DBType of dbType when it names an enum, as
+// schema.name, and dbType otherwise.
+func (cat *catalog) enumDBType(schema, dbType string) string {
+	if enum, ok := cat.lookupEnum(dbType); ok {
+		return enum.qualify(schema).String()
+	}
+	return dbType
+}
+
+// enumLabelLines returns the enum-label lines of dbType when it names an enum
+// with labels its DBType can't list, see enumType.labelLines.
+func (cat *catalog) enumLabelLines(schema, dbType string) []string {
+	if enum, ok := cat.lookupEnum(dbType); ok {
+		return enum.qualify(schema).labelLines()
+	}
+	return nil
+}
+
+// lookupEnum returns the enum dbType names, as schema.name.
+func (cat *catalog) lookupEnum(dbType string) (enumType, bool) {
+	if cat.enums == nil {
+		return enumType{}, false
+	}
+
+	// The region enum of a multi-region database may be reported unqualified,
+	// it always lives in the public schema.
+	if dbType == regionEnum {
+		dbType = "public." + regionEnum
+	}
+	if !strings.Contains(dbType, ".") {
+		return enumType{}, false
+	}
+
+	parts := strings.Split(dbType, ".")
+	if len(parts) != 2 {
+		return enumType{}, false
+	}
+	for _, enum := range cat.enums {
+		if enum.schema == parts[0] && enum.name == parts[1] {
+			return enum, true
+		}
+	}
+
+	return enumType{}, false
+}
+
+// qualify prefixes the enum name with its schema when the enum lives outside
+// of schema, so that enums of the same name in different schemas don't clash
+// and the generated type names point at where the enum actually is.
+func (e enumType) qualify(schema string) enumType {
+	// There is a single region enum per database, its name is kept as is so
+	// every schema shares the same region type.
+	if e.schema != schema && e.name != regionEnum {
+		e.name = e.schema + "_" + e.name
+	}
+	return e
+}
+
+func (e enumType) String() string {
+	// format understandable to drivers.FilterColumnsByEnum, strmangle.ParseEnumName and strmangle.ParseEnumVals,
+	// the labels are kept as they are for randomize, only the templates escape them
+	values := make([]string, 0, len(e.values))
+	for _, v := range e.values {
+		if isEnumLabelListable(v) {
+			values = append(values, v)
+		}
+	}
+	return fmt.Sprintf("enum.%s('%s')", e.name, strings.Join(values, "','"))
+}
+
+// labelLines returns an enum: line with the name of the enum followed by an
+// enum-label: line per label, quoted as a Go string, when some labels are left
+// out of the enum DBType, so that the templates still know every label. The
+// DBType then only lists the labels randomize can use, if any.
+func (e enumType) labelLines() []string {
+	listable := true
+	for _, v := range e.values {
+		listable = listable && isEnumLabelListable(v)
+	}
+	if listable {
+		return nil
+	}
+
+	lines := []string{"enum: " + e.name}
+	for _, v := range e.values {
+		lines = append(lines, "enum-label: "+strconv.Quote(v))
+	}
+	return lines
+}
+
+// isEnumLabelListable tells whether an enum label can be listed in the enum
+// DBType. The format has no escaping, a single quote would end the label, and
+// boil_types writes the labels of enums it deems normal between double quotes
+// as they are, where a tab or a line break can't go.
+func isEnumLabelListable(v string) bool {
+	return !strings.ContainsAny(v, "'\t\n\f\r")
+}
+
+// hasEnumLabelLines tells whether the comment of a column lists the labels of
+// its enum in enum-label lines. Such enums are strings, as the enum types of
+// boil_types would be missing labels.
+func hasEnumLabelLines(comment string) bool {
+	for _, line := range strings.Split(comment, "\n") {
+		if strings.HasPrefix(line, "enum-label: ") {
+			return true
+		}
+	}
+	return false
+}
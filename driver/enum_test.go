@@ -0,0 +1,39 @@
+package driver
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/strmangle"
+)
+
+func TestEnumTypeString(t *testing.T) {
+	t.Parallel()
+
+	e := enumType{name: "workday", values: []string{"monday", `it's`, "a','b", `{x|y}`, `"quoted" \ back`, "a,b", "tab\tday"}}
+	dbType := e.String()
+
+	if name := strmangle.ParseEnumName(dbType); name != "workday" {
+		t.Errorf("want name workday, got %q from %s", name, dbType)
+	}
+
+	// Labels with a single quote or a line break can't be listed, the others
+	// are kept raw for randomize
+	want := []string{"monday", `{x|y}`, `"quoted" \ back`, "a,b"}
+	if vals := strmangle.ParseEnumVals(dbType); !reflect.DeepEqual(vals, want) {
+		t.Errorf("want values %q, got %q from %s", want, vals, dbType)
+	}
+
+	// All of them are in the enum-label lines
+	wantLines := []string{
+		"enum: workday",
+		`enum-label: "monday"`, `enum-label: "it's"`, `enum-label: "a','b"`, `enum-label: "{x|y}"`,
+		`enum-label: "\"quoted\" \\ back"`, `enum-label: "a,b"`, `enum-label: "tab\tday"`,
+	}
+	if lines := e.labelLines(); !reflect.DeepEqual(lines, wantLines) {
+		t.Errorf("want lines %q, got %q", wantLines, lines)
+	}
+	if lines := (enumType{name: "workday", values: []string{"monday", "a,b"}}).labelLines(); lines != nil {
+		t.Errorf("want no lines for listable labels, got %q", lines)
+	}
+}
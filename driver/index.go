@@ -0,0 +1,254 @@
+package driver
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/strmangle"
+)
+
+var (
+	rgxIndexPredicate = regexp.MustCompile(`\) WHERE (.+)$`)
+	rgxIndexInverted  = regexp.MustCompile(`(?i)(USING gin|INVERTED INDEX)`)
+	rgxIndexHash      = regexp.MustCompile(`(?i)USING HASH`)
+)
+
+type (
+	// index is a primary, unique or secondary index of a table.
+	index struct {
+		name    string
+		columns []indexColumn
+		// storing are the columns the index stores without indexing them
+		storing []string
+
+		primary bool
+		unique  bool
+		// predicate is the WHERE clause of a partial index
+		predicate string
+		inverted  bool
+		sharded   bool
+		// expression is an index on expressions rather than only columns
+		expression bool
+	}
+	indexColumn struct {
+		name       string
+		descending bool
+	}
+)
+
+// keyColumns returns the columns of the index that the generated models
+// know, without the implicit shard and expression columns.
+func (i index) keyColumns() []string {
+	var columns []string
+	for _, c := range i.columns {
+		if strings.HasPrefix(c.name, "crdb_internal_") {
+			continue
+		}
+		columns = append(columns, c.name)
+	}
+	return columns
+}
+
+// uniqueKey tells whether the index makes its key columns unique across all
+// rows of the table, partial and expression indexes don't.
+func (i index) uniqueKey() bool {
+	return i.unique && i.predicate == "" && !i.expression && !i.inverted
+}
+
+// loadIndexes reads every index of the tables with its columns in key order,
+// then marks the columns that a unique index covers by itself.
+func (d *CockroachDBDriver) loadIndexes(cat *catalog, schemas []string) error {
+	if !d.caps.indexes {
+		return nil
+	}
+
+	query := `SELECT
+	s.table_schema,
+	s.table_name,
+	s.index_name,
+	s.non_unique = 'NO' AS is_unique,
+	s.column_name,
+	s.direction = 'DESC' AS is_descending,
+	s.storing = 'YES' AS is_storing,
+	s.implicit = 'YES' AS is_implicit,
+	coalesce(i.indexdef, '') AS indexdef
+FROM
+	information_schema.statistics AS s
+	LEFT JOIN pg_indexes AS i
+	ON
+		s.table_schema = i.schemaname
+		AND s.table_name = i.tablename
+		AND s.index_name = i.indexname
+WHERE
+	s.table_schema = ANY($1)
+ORDER BY
+	s.table_schema, s.table_name, s.index_name, s.seq_in_index ASC;`
+
+	rows, err := d.conn.Query(query, pq.Array(schemas))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	indexes := make(map[tableKey]map[string]*index)
+	for rows.Next() {
+		var key tableKey
+		var name, column, def string
+		var unique, descending, storing, implicit bool
+		if err := rows.Scan(&key.schema, &key.name, &name, &unique, &column, &descending, &storing, &implicit, &def); err != nil {
+			return errors.Wrap(err, "unable to scan indexes")
+		}
+
+		byName, ok := indexes[key]
+		if !ok {
+			byName = make(map[string]*index)
+			indexes[key] = byName
+		}
+		idx, ok := byName[name]
+		if !ok {
+			idx = newIndex(name, unique, def)
+			if pkey, ok := cat.primaryKeys[key]; ok && pkey.Name == name {
+				idx.primary = true
+			}
+			byName[name] = idx
+			cat.indexes[key] = append(cat.indexes[key], idx)
+		}
+
+		switch {
+		case storing:
+			idx.storing = append(idx.storing, column)
+		case implicit:
+			// Secondary indexes implicitly end with the primary key columns
+			// and REGIONAL BY ROW indexes start with the region, neither is
+			// part of what makes the index unique.
+		default:
+			if strings.HasPrefix(column, "crdb_internal_idx_expr") {
+				idx.expression = true
+			}
+			idx.columns = append(idx.columns, indexColumn{name: column, descending: descending})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for key, idxs := range cat.indexes {
+		for _, idx := range idxs {
+			for _, c := range idx.columns {
+				if cat.isShardColumn(key, c.name) {
+					idx.sharded = true
+				}
+			}
+
+			columns := idx.keyColumns()
+			if !idx.uniqueKey() || len(columns) != 1 {
+				continue
+			}
+			cols := cat.columns[key]
+			for i := range cols {
+				if cols[i].name == columns[0] {
+					cols[i].unique = true
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// newIndex creates an index from its definition as in pg_indexes.indexdef.
+func newIndex(name string, unique bool, def string) *index {
+	idx := &index{
+		name:     name,
+		unique:   unique,
+		inverted: rgxIndexInverted.MatchString(def),
+		sharded:  rgxIndexHash.MatchString(def),
+	}
+	if m := rgxIndexPredicate.FindStringSubmatch(def); m != nil {
+		idx.predicate = strings.TrimSuffix(strings.TrimPrefix(m[1], "("), ")")
+	}
+	return idx
+}
+
+// isShardColumn tells whether column is the shard column of a hash-sharded
+// index of the table.
+func (cat *catalog) isShardColumn(key tableKey, column string) bool {
+	for _, shard := range cat.shards[key] {
+		if shard.name == column {
+			return true
+		}
+	}
+	return false
+}
+
+// shardBuckets returns the number of buckets of the hash-sharded index that
+// hashes column, or 0 when no hash-sharded index has it as a key column.
+func (cat *catalog) shardBuckets(key tableKey, column string) int {
+	for _, idx := range cat.indexes[key] {
+		if !strmangle.SetInclude(column, idx.keyColumns()) {
+			continue
+		}
+		for _, c := range idx.columns {
+			for _, shard := range cat.shards[key] {
+				if shard.name == c.name {
+					return shard.buckets
+				}
+			}
+		}
+	}
+	return 0
+}
+
+// indexDefinition describes idx like CockroachDB's SHOW CREATE, as in
+// UNIQUE INDEX (ts DESC, id) STORING (note) USING HASH WITH (bucket_count=16),
+// for the doc comments of the indexes template.
+func (cat *catalog) indexDefinition(key tableKey, idx *index) string {
+	var b strings.Builder
+	switch {
+	case idx.primary:
+		b.WriteString("PRIMARY KEY")
+	case idx.unique:
+		b.WriteString("UNIQUE INDEX")
+	case idx.inverted:
+		b.WriteString("INVERTED INDEX")
+	default:
+		b.WriteString("INDEX")
+	}
+
+	buckets := 0
+	var columns []string
+	for _, c := range idx.columns {
+		if cat.isShardColumn(key, c.name) {
+			for _, shard := range cat.shards[key] {
+				if shard.name == c.name {
+					buckets = shard.buckets
+				}
+			}
+			continue
+		}
+		if c.descending {
+			columns = append(columns, c.name+" DESC")
+		} else {
+			columns = append(columns, c.name)
+		}
+	}
+	fmt.Fprintf(&b, " (%s)", strings.Join(columns, ", "))
+
+	if len(idx.storing) > 0 {
+		fmt.Fprintf(&b, " STORING (%s)", strings.Join(idx.storing, ", "))
+	}
+	if idx.sharded {
+		b.WriteString(" USING HASH")
+		if buckets > 0 {
+			fmt.Fprintf(&b, " WITH (bucket_count=%d)", buckets)
+		}
+	}
+	if idx.predicate != "" {
+		b.WriteString(" WHERE " + idx.predicate)
+	}
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
@@ -0,0 +1,107 @@
+package driver
+
+import (
+	"testing"
+)
+
+func TestNewIndex(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		def  string
+		want index
+	}{
+		{
+			"CREATE UNIQUE INDEX users_email_key ON app.public.users USING btree (email ASC)",
+			index{name: "i", unique: true},
+		},
+		{
+			"CREATE UNIQUE INDEX users_active_email ON app.public.users USING btree (email ASC) WHERE (deleted_at IS NULL)",
+			index{name: "i", unique: true, predicate: "deleted_at IS NULL"},
+		},
+		{
+			"CREATE INDEX docs_data_idx ON app.public.docs USING gin (data)",
+			index{name: "i", inverted: true},
+		},
+		{
+			"CREATE INDEX events_ts_idx ON app.public.events USING btree (ts ASC) USING HASH WITH (bucket_count=16)",
+			index{name: "i", sharded: true},
+		},
+	}
+
+	for _, test := range tests {
+		got := newIndex("i", test.want.unique, test.def)
+		if got.predicate != test.want.predicate || got.inverted != test.want.inverted || got.sharded != test.want.sharded {
+			t.Errorf("%s: want %+v, got %+v", test.def, test.want, *got)
+		}
+	}
+}
+
+func TestIndexUniqueKey(t *testing.T) {
+	t.Parallel()
+
+	idx := index{
+		unique:  true,
+		columns: []indexColumn{{name: "crdb_internal_email_shard_8"}, {name: "email"}},
+	}
+	if got := idx.keyColumns(); len(got) != 1 || got[0] != "email" {
+		t.Errorf("want the email key column, got %v", got)
+	}
+	if !idx.uniqueKey() {
+		t.Error("want a unique key")
+	}
+
+	idx.predicate = "deleted_at IS NULL"
+	if idx.uniqueKey() {
+		t.Error("a partial index isn't unique across all rows")
+	}
+}
+
+func TestShardBuckets(t *testing.T) {
+	t.Parallel()
+
+	key := tableKey{"public", "events"}
+	cat := &catalog{
+		indexes: map[tableKey][]*index{key: {
+			{name: "events_pkey", primary: true, columns: []indexColumn{{name: "id"}}},
+			{name: "events_ts_idx", sharded: true, columns: []indexColumn{{name: "crdb_internal_ts_shard_16"}, {name: "ts"}}},
+		}},
+		shards: map[tableKey][]shardColumn{key: {{name: "crdb_internal_ts_shard_16", buckets: 16}}},
+	}
+
+	if got := cat.shardBuckets(key, "ts"); got != 16 {
+		t.Errorf("want 16 buckets for ts, got %d", got)
+	}
+	if got := cat.shardBuckets(key, "id"); got != 0 {
+		t.Errorf("want no buckets for id, got %d", got)
+	}
+}
+
+func TestIndexDefinition(t *testing.T) {
+	t.Parallel()
+
+	key := tableKey{"public", "events"}
+	cat := &catalog{shards: map[tableKey][]shardColumn{key: {{name: "crdb_internal_ts_shard_16", buckets: 16}}}}
+
+	tests := []struct {
+		idx  index
+		want string
+	}{
+		{index{primary: true, unique: true, columns: []indexColumn{{name: "id"}}}, "PRIMARY KEY (id)"},
+		{
+			index{unique: true, predicate: "deleted_at IS NULL", columns: []indexColumn{{name: "org_id"}, {name: "slug", descending: true}}},
+			"UNIQUE INDEX (org_id, slug DESC) WHERE deleted_at IS NULL",
+		},
+		{
+			index{sharded: true, storing: []string{"kind", "data"}, columns: []indexColumn{{name: "crdb_internal_ts_shard_16"}, {name: "ts", descending: true}}},
+			"INDEX (ts DESC) STORING (kind, data) USING HASH WITH (bucket_count=16)",
+		},
+		{index{inverted: true, columns: []indexColumn{{name: "data"}}}, "INVERTED INDEX (data)"},
+	}
+
+	for _, test := range tests {
+		if got := cat.indexDefinition(key, &test.idx); got != test.want {
+			t.Errorf("want %s, got %s", test.want, got)
+		}
+	}
+}
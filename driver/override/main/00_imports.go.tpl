@@ -0,0 +1,49 @@
+{{- /*
+The packages of the go:import annotations of the column comments (see
+parseAnnotations in the driver). The driver binary is asked for its imports in
+a process of its own, without the columns, so they are imported here instead,
+in a declaration of their own after the one of the file header. Go doesn't
+allow a package to be imported twice in a file, so the packages the header
+imports for every model and for the types of the columns are left out.
+*/ -}}
+{{- $imported := onceNew -}}
+{{- range $pkg := splitLines `database/sql
+fmt
+reflect
+strconv
+strings
+sync
+time
+github.com/friendsofgo/errors
+github.com/volatiletech/sqlboiler/v4/boil
+github.com/volatiletech/sqlboiler/v4/queries
+github.com/volatiletech/sqlboiler/v4/queries/qm
+github.com/volatiletech/sqlboiler/v4/queries/qmhelper
+github.com/volatiletech/strmangle` -}}
+	{{- $_ := $imported.Put $pkg -}}
+{{- end -}}
+{{- if not .NoContext -}}
+	{{- $_ := $imported.Put "context" -}}
+{{- end -}}
+{{- range $col := .Table.Columns -}}
+	{{- if and (gt (len $col.Type) 5) (eq (slice $col.Type 0 5) "null.") -}}
+		{{- $_ := $imported.Put "github.com/volatiletech/null/v8" -}}
+	{{- else if and (gt (len $col.Type) 6) (eq (slice $col.Type 0 6) "types.") -}}
+		{{- $_ := $imported.Put "github.com/volatiletech/sqlboiler/v4/types" -}}
+	{{- else if and (gt (len $col.Type) 10) (eq (slice $col.Type 0 10) "crdbtypes.") -}}
+		{{- $_ := $imported.Put "github.com/dgollings/sqlboiler-crdb/v4/crdbtypes" -}}
+	{{- end -}}
+{{- end -}}
+{{- $imports := "" -}}
+{{- range $col := .Table.Columns -}}
+	{{- range $line := splitLines $col.Comment -}}
+		{{- if and (gt (len $line) 10) (eq (slice $line 0 10) "go:import=") ($imported.Put (slice $line 10)) -}}
+			{{- $imports = printf "%s\n\t%q" $imports (slice $line 10) -}}
+		{{- end -}}
+	{{- end -}}
+{{- end -}}
+{{- if $imports}}
+
+import ({{$imports}}
+)
+{{end -}}
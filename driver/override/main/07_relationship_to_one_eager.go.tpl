@@ -0,0 +1,173 @@
+{{- /*
+sqlboiler's to-one eager loading template, with the hidden columns the driver
+exposes (see the all template) selected along with the others when the load
+mods select no columns.
+*/ -}}
+{{- if or .Table.IsJoinTable .Table.IsView -}}
+{{- else -}}
+	{{- range $fkey := .Table.FKeys -}}
+		{{- $ltable := $.Aliases.Table $fkey.Table -}}
+		{{- $ftable := $.Aliases.Table $fkey.ForeignTable -}}
+		{{- $rel := $ltable.Relationship $fkey.Name -}}
+		{{- $arg := printf "maybe%s" $ltable.UpSingular -}}
+		{{- $col := $ltable.Column $fkey.Column -}}
+		{{- $fcol := $ftable.Column $fkey.ForeignColumn -}}
+		{{- $usesPrimitives := usesPrimitives $.Tables $fkey.Table $fkey.Column $fkey.ForeignTable $fkey.ForeignColumn -}}
+		{{- $canSoftDelete := (getTable $.Tables $fkey.ForeignTable).CanSoftDelete $.AutoColumns.Deleted -}}
+		{{- $hidden := false -}}
+		{{- range $c := (getTable $.Tables $fkey.ForeignTable).Columns -}}
+			{{- range $line := splitLines $c.Comment -}}
+				{{- if eq $line "hidden: true" -}}
+					{{- $hidden = true -}}
+				{{- end -}}
+			{{- end -}}
+		{{- end}}
+// Load{{$rel.Foreign}} allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func ({{$ltable.DownSingular}}L) Load{{$rel.Foreign}}({{if $.NoContext}}e boil.Executor{{else}}ctx context.Context, e boil.ContextExecutor{{end}}, singular bool, {{$arg}} interface{}, mods queries.Applicator) error {
+	var slice []*{{$ltable.UpSingular}}
+	var object *{{$ltable.UpSingular}}
+
+	if singular {
+		object = {{$arg}}.(*{{$ltable.UpSingular}})
+	} else {
+		slice = *{{$arg}}.(*[]*{{$ltable.UpSingular}})
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &{{$ltable.DownSingular}}R{}
+		}
+		{{if $usesPrimitives -}}
+		args = append(args, object.{{$col}})
+		{{else -}}
+		if !queries.IsNil(object.{{$col}}) {
+			args = append(args, object.{{$col}})
+		}
+		{{end}}
+	} else {
+		Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &{{$ltable.DownSingular}}R{}
+			}
+
+			for _, a := range args {
+				{{if $usesPrimitives -}}
+				if a == obj.{{$col}} {
+				{{else -}}
+				if queries.Equal(a, obj.{{$col}}) {
+				{{end -}}
+					continue Outer
+				}
+			}
+
+			{{if $usesPrimitives -}}
+			args = append(args, obj.{{$col}})
+			{{else -}}
+			if !queries.IsNil(obj.{{$col}}) {
+				args = append(args, obj.{{$col}})
+			}
+			{{end}}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+	    qm.From(`{{if $.Dialect.UseSchema}}{{$.Schema}}.{{end}}{{.ForeignTable}}`),
+	    qm.WhereIn(`{{if $.Dialect.UseSchema}}{{$.Schema}}.{{end}}{{.ForeignTable}}.{{.ForeignColumn}} in ?`, args...),
+	    {{if and $.AddSoftDeletes $canSoftDelete -}}
+	    qmhelper.WhereIsNull(`{{if $.Dialect.UseSchema}}{{$.Schema}}.{{end}}{{.ForeignTable}}.deleted_at`),
+	    {{- end}}
+    )
+	if mods != nil {
+		mods.Apply(query)
+	}
+	{{- if $hidden}}
+	if len(queries.GetSelect(query)) == 0 {
+		queries.SetSelect(query, {{$ftable.DownSingular}}SelectColumns)
+	}
+	{{- end}}
+
+	{{if $.NoContext -}}
+	results, err := query.Query(e)
+	{{else -}}
+	results, err := query.QueryContext(ctx, e)
+	{{end -}}
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load {{$ftable.UpSingular}}")
+	}
+
+	var resultSlice []*{{$ftable.UpSingular}}
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice {{$ftable.UpSingular}}")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for {{.ForeignTable}}")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for {{.ForeignTable}}")
+	}
+
+	{{if not $.NoHooks -}}
+	if len({{$ltable.DownSingular}}AfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks({{if $.NoContext}}e{{else}}ctx, e{{end}}); err != nil {
+				return err
+			}
+		}
+	}
+	{{- end}}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.{{$rel.Foreign}} = foreign
+		{{if not $.NoBackReferencing -}}
+		if foreign.R == nil {
+			foreign.R = &{{$ftable.DownSingular}}R{}
+		}
+			{{if $fkey.Unique -}}
+		foreign.R.{{$rel.Local}} = object
+			{{else -}}
+		foreign.R.{{$rel.Local}} = append(foreign.R.{{$rel.Local}}, object)
+			{{end -}}
+		{{end -}}
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			{{if $usesPrimitives -}}
+			if local.{{$col}} == foreign.{{$fcol}} {
+			{{else -}}
+			if queries.Equal(local.{{$col}}, foreign.{{$fcol}}) {
+			{{end -}}
+				local.R.{{$rel.Foreign}} = foreign
+				{{if not $.NoBackReferencing -}}
+				if foreign.R == nil {
+					foreign.R = &{{$ftable.DownSingular}}R{}
+				}
+					{{if $fkey.Unique -}}
+				foreign.R.{{$rel.Local}} = local
+					{{else -}}
+				foreign.R.{{$rel.Local}} = append(foreign.R.{{$rel.Local}}, local)
+					{{end -}}
+				{{end -}}
+				break
+			}
+		}
+	}
+
+	return nil
+}
+{{end -}}{{/* range */}}
+{{end}}{{/* join table */}}
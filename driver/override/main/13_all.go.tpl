@@ -0,0 +1,50 @@
+{{- /*
+sqlboiler's all template, with the hidden columns the driver exposes (see the
+hidden: lines of the column comments) selected after the others by default, as
+SELECT * leaves them out and the rows would come back with zero values.
+*/ -}}
+{{- $alias := .Aliases.Table .Table.Name}}
+{{- $schemaTable := .Table.Name | .SchemaTable}}
+{{- $canSoftDelete := .Table.CanSoftDelete $.AutoColumns.Deleted }}
+{{- $hidden := false -}}
+{{- range $col := .Table.Columns -}}
+	{{- range $line := splitLines $col.Comment -}}
+		{{- if eq $line "hidden: true" -}}
+			{{- $hidden = true -}}
+		{{- end -}}
+	{{- end -}}
+{{- end}}
+{{- if $hidden}}
+
+// {{$alias.DownSingular}}SelectColumns are the columns {{$alias.UpSingular}} queries select when
+// they select no others, the hidden ones after all the visible ones.
+var {{$alias.DownSingular}}SelectColumns = []string{
+	"{{$schemaTable}}.*",
+	{{- range $col := .Table.Columns -}}
+	{{- range $line := splitLines $col.Comment -}}
+	{{- if eq $line "hidden: true"}}
+	"{{$schemaTable}}.{{$col.Name | $.Quotes}} AS {{$col.Name | $.Quotes}}",
+	{{- end -}}
+	{{- end -}}
+	{{- end}}
+}
+{{- end}}
+
+// {{$alias.UpPlural}} retrieves all the records using an executor.
+func {{$alias.UpPlural}}(mods ...qm.QueryMod) {{$alias.DownSingular}}Query {
+    {{if and .AddSoftDeletes $canSoftDelete -}}
+    mods = append(mods, qm.From("{{$schemaTable}}"), qmhelper.WhereIsNull("{{$schemaTable}}.{{"deleted_at" | $.Quotes}}"))
+    {{else -}}
+	mods = append(mods, qm.From("{{$schemaTable}}"))
+	{{end -}}
+	{{if $hidden -}}
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, {{$alias.DownSingular}}SelectColumns)
+	}
+
+	return {{$alias.DownSingular}}Query{q}
+	{{- else -}}
+	return {{$alias.DownSingular}}Query{NewQuery(mods...)}
+	{{- end}}
+}
@@ -92,6 +92,13 @@ func (o *{{$alias.UpSingular}}) Upsert({{if .NoContext}}exec boil.Executor{{else
 			{{$alias.DownSingular}}AllColumns,
 			{{$alias.DownSingular}}PrimaryKeyColumns,
 		)
+		{{- if filterColumnsByAuto true .Table.Columns }}
+
+		// Generated columns can't be written, but their values are always read back
+		insert = strmangle.SetComplement(insert, {{$alias.DownSingular}}GeneratedColumns)
+		update = strmangle.SetComplement(update, {{$alias.DownSingular}}GeneratedColumns)
+		ret = strmangle.SetMerge(ret, {{$alias.DownSingular}}GeneratedColumns)
+		{{- end}}
 
 		if updateOnConflict && len(update) == 0 {
 			return errors.New("{{.PkgName}}: unable to upsert {{.Table.Name}}, could not build update column list")
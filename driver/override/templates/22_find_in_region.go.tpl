@@ -0,0 +1,64 @@
+{{- if and (not .Table.IsView) .Table.PKey -}}
+{{- $regionCol := "" -}}
+{{- $regionType := "" -}}
+{{- range $col := .Table.Columns | filterColumnsByEnum -}}
+	{{- if and (eq $regionCol "") (eq (parseEnumName $col.DBType) "crdb_internal_region") (not $col.Nullable) (not (setInclude $col.Name $.Table.PKey.Columns)) -}}
+		{{- $regionCol = $col.Name -}}
+		{{- $regionType = $col.Type -}}
+	{{- end -}}
+{{- end -}}
+{{- if ne $regionCol "" -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $colDefs := sqlColDefinitions .Table.Columns .Table.PKey.Columns -}}
+{{- $pkNames := $colDefs.Names | stringMap (aliasCols $alias) | stringMap .StringFuncs.camelCase | stringMap .StringFuncs.replaceReserved -}}
+{{- $pkArgs := joinSlices " " $pkNames $colDefs.Types | join ", " -}}
+{{- $canSoftDelete := .Table.CanSoftDelete $.AutoColumns.Deleted -}}
+{{- $hidden := false -}}
+{{- range $col := .Table.Columns -}}
+	{{- range $line := splitLines $col.Comment -}}
+		{{- if eq $line "hidden: true" -}}
+			{{- $hidden = true -}}
+		{{- end -}}
+	{{- end -}}
+{{- end}}
+// Find{{$alias.UpSingular}}InRegion retrieves a single record by ID from the
+// rows of region. On a REGIONAL BY ROW table the lookup then stays in that
+// region's partition instead of searching every region.
+// If selectCols is empty Find will return all columns.
+func Find{{$alias.UpSingular}}InRegion({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, region {{$regionType}}, {{$pkArgs}}, selectCols ...string) (*{{$alias.UpSingular}}, error) {
+	{{$alias.DownSingular}}Obj := &{{$alias.UpSingular}}{}
+
+	{{if $hidden -}}
+	sel := strings.Join({{$alias.DownSingular}}SelectColumns, ",")
+	{{- else -}}
+	sel := "*"
+	{{- end}}
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from {{.Table.Name | .SchemaTable}} where {{$regionCol | $.Quotes}}=$1 AND {{whereClause .LQ .RQ 2 .Table.PKey.Columns}}{{if and .AddSoftDeletes $canSoftDelete}} and {{"deleted_at" | $.Quotes}} is null{{end}}", sel,
+	)
+
+	q := queries.Raw(query, region, {{$pkNames | join ", "}})
+
+	err := q.Bind({{if not .NoContext}}ctx{{else}}nil{{end}}, exec, {{$alias.DownSingular}}Obj)
+	if err != nil {
+		{{if not .AlwaysWrapErrors -}}
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		{{end -}}
+		return nil, errors.Wrap(err, "{{.PkgName}}: unable to select from {{.Table.Name}}")
+	}
+
+	{{if not .NoHooks -}}
+	if err = {{$alias.DownSingular}}Obj.doAfterSelectHooks({{if not .NoContext}}ctx, {{end -}} exec); err != nil {
+		return {{$alias.DownSingular}}Obj, err
+	}
+	{{- end}}
+
+	return {{$alias.DownSingular}}Obj, nil
+}
+{{- end -}}
+{{- end -}}
@@ -0,0 +1,32 @@
+{{- if and (not .Table.IsView) .Table.Columns -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $expireAfter := "" -}}
+{{- $expression := "" -}}
+{{- range $line := splitLines (index .Table.Columns 0).Comment -}}
+	{{- if and (gt (len $line) 18) (eq (slice $line 0 18) "ttl: expire_after=") -}}
+		{{- $expireAfter = slice $line 18 -}}
+	{{- else if and (gt (len $line) 27) (eq (slice $line 0 27) "ttl: expiration_expression=") -}}
+		{{- $expression = slice $line 27 -}}
+	{{- end -}}
+{{- end -}}
+{{- if or $expireAfter $expression}}
+
+// {{$alias.UpSingular}}RowLevelTTL tells that {{.Table.Name}} has a row-level TTL, its
+// rows are deleted in the background once they have expired. A row that was
+// read or written before may be gone.
+const {{$alias.UpSingular}}RowLevelTTL = true
+{{- if $expireAfter}}
+
+// {{$alias.UpSingular}}TTLExpireAfter is the ttl_expire_after of {{.Table.Name}}, rows
+// expire that long after they were last written, as kept in
+// crdb_internal_expiration.
+const {{$alias.UpSingular}}TTLExpireAfter = {{printf "%q" $expireAfter}}
+{{- end}}
+{{- if $expression}}
+
+// {{$alias.UpSingular}}TTLExpirationExpression is the ttl_expiration_expression of
+// {{.Table.Name}}, the time its rows expire at.
+const {{$alias.UpSingular}}TTLExpirationExpression = {{printf "%q" $expression}}
+{{- end}}
+{{- end -}}
+{{- end -}}
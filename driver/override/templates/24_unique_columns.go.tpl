@@ -0,0 +1,153 @@
+{{- if and (not .Table.IsView) .Table.PKey -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $canSoftDelete := .Table.CanSoftDelete $.AutoColumns.Deleted -}}
+{{- $soft := and .AddSoftDeletes $canSoftDelete -}}
+{{- $hidden := false -}}
+{{- range $col := .Table.Columns -}}
+	{{- range $line := splitLines $col.Comment -}}
+		{{- if eq $line "hidden: true" -}}
+			{{- $hidden = true -}}
+		{{- end -}}
+	{{- end -}}
+{{- end -}}
+{{- $targets := "" -}}
+{{- $seen := onceNew -}}
+{{- $lines := splitLines (index .Table.Columns 0).Comment -}}
+{{- range $line := $lines -}}
+{{- if and (gt (len $line) 8) (eq (slice $line 0 8) "unique: ") -}}
+{{- $name := slice $line 8 -}}
+{{- /* The unique-column: lines right after the unique: line of the key */ -}}
+{{- $keyCols := "" -}}
+{{- $in := false -}}
+{{- range $l := $lines -}}
+	{{- if and (gt (len $l) 8) (eq (slice $l 0 8) "unique: ") -}}
+		{{- $in = eq (slice $l 8) $name -}}
+	{{- else if and $in (gt (len $l) 15) (eq (slice $l 0 15) "unique-column: ") -}}
+		{{- if $keyCols -}}
+			{{- $keyCols = printf "%s\n%s" $keyCols (slice $l 15) -}}
+		{{- else -}}
+			{{- $keyCols = slice $l 15 -}}
+		{{- end -}}
+	{{- else -}}
+		{{- $in = false -}}
+	{{- end -}}
+{{- end -}}
+{{- $cols := splitLines $keyCols -}}
+{{- $known := true -}}
+{{- range $c := $cols -}}
+	{{- if not (setInclude $c (columnNames $.Table.Columns)) -}}
+		{{- $known = false -}}
+	{{- end -}}
+{{- end -}}
+{{- $single := eq (len $cols) 1 -}}
+{{- if and $known (not (and $single (setInclude (index $cols 0) $.Table.PKey.Columns))) -}}
+{{- $suffix := $cols | stringMap (aliasCols $alias) | join "" -}}
+{{- if $seen.Put $suffix -}}
+{{- $colDefs := sqlColDefinitions $.Table.Columns $cols -}}
+{{- $argNames := $cols | stringMap (aliasCols $alias) | stringMap $.StringFuncs.camelCase | stringMap $.StringFuncs.replaceReserved -}}
+{{- $params := joinSlices " " $argNames $colDefs.Types | join ", " -}}
+{{- $args := $argNames | join ", " -}}
+{{- $target := printf "%sConflictOn%s" $alias.UpSingular (titleCase $name) -}}
+{{- $desc := printf "unique key %s (%s)" $name ($cols | join ", ") -}}
+{{- if $single -}}
+	{{- $target = printf "%sConflictOn%s" $alias.UpSingular $suffix -}}
+	{{- $desc = printf "unique %s" (index $cols 0) -}}
+{{- end -}}
+{{- $targets = printf "%s\n\t%s," $targets $target}}
+
+// {{$target}} is the conflict target of an upsert on the
+// {{$desc}}.
+var {{$target}} = []string{ {{- $cols | stringMap $.StringFuncs.quoteWrap | join ", " -}} }
+
+// Find{{$alias.UpSingular}}By{{$suffix}} retrieves a single record by its {{$desc}}.
+// If selectCols is empty Find will return all columns.
+func Find{{$alias.UpSingular}}By{{$suffix}}({{if $.NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, {{$params}}, selectCols ...string) (*{{$alias.UpSingular}}, error) {
+	{{$alias.DownSingular}}Obj := &{{$alias.UpSingular}}{}
+
+	{{if $hidden -}}
+	sel := strings.Join({{$alias.DownSingular}}SelectColumns, ",")
+	{{- else -}}
+	sel := "*"
+	{{- end}}
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from {{$.Table.Name | $.SchemaTable}} where {{whereClause $.LQ $.RQ 1 $cols}}{{if and $.AddSoftDeletes $canSoftDelete}} and {{"deleted_at" | $.Quotes}} is null{{end}}", sel,
+	)
+
+	q := queries.Raw(query, {{$args}})
+
+	err := q.Bind({{if not $.NoContext}}ctx{{else}}nil{{end}}, exec, {{$alias.DownSingular}}Obj)
+	if err != nil {
+		{{if not $.AlwaysWrapErrors -}}
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		{{end -}}
+		return nil, errors.Wrap(err, "{{$.PkgName}}: unable to select from {{$.Table.Name}}")
+	}
+
+	{{if not $.NoHooks -}}
+	if err = {{$alias.DownSingular}}Obj.doAfterSelectHooks({{if not $.NoContext}}ctx, {{end -}} exec); err != nil {
+		return {{$alias.DownSingular}}Obj, err
+	}
+	{{- end}}
+
+	return {{$alias.DownSingular}}Obj, nil
+}
+
+// {{$alias.UpSingular}}ExistsBy{{$suffix}} checks if the {{$alias.UpSingular}} row with the {{$desc}} exists.
+func {{$alias.UpSingular}}ExistsBy{{$suffix}}({{if $.NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, {{$params}}) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from {{$.Table.Name | $.SchemaTable}} where {{whereClause $.LQ $.RQ 1 $cols}}{{if and $.AddSoftDeletes $canSoftDelete}} and {{"deleted_at" | $.Quotes}} is null{{end}} limit 1)"
+
+	{{if $.NoContext -}}
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, {{$args}})
+	}
+	{{else -}}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, {{$args}})
+	}
+	{{end -}}
+
+	{{if $.NoContext -}}
+	row := exec.QueryRow(sql, {{$args}})
+	{{else -}}
+	row := exec.QueryRowContext(ctx, sql, {{$args}})
+	{{- end}}
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "{{$.PkgName}}: unable to check if {{$.Table.Name}} exists")
+	}
+
+	return exists, nil
+}
+
+// Delete{{$alias.UpSingular}}By{{$suffix}} deletes the {{$alias.UpSingular}} row with the {{$desc}}.
+// The row is looked up first so that the delete hooks run.
+func Delete{{$alias.UpSingular}}By{{$suffix}}({{if $.NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, {{$params}}{{if $soft}}, hardDelete bool{{end}}) {{if $.NoRowsAffected}}error{{else}}(int64, error){{end -}} {
+	o, err := Find{{$alias.UpSingular}}By{{$suffix}}({{if not $.NoContext}}ctx, {{end -}} exec, {{$args}})
+	if err != nil {
+		return {{if not $.NoRowsAffected}}0, {{end -}} err
+	}
+
+	return o.Delete({{if not $.NoContext}}ctx, {{end -}} exec{{if $soft}}, hardDelete{{end}})
+}
+{{- end -}}
+{{- end -}}
+{{- end -}}
+{{- end}}
+
+// {{$alias.UpSingular}}ConflictTargets are the columns of the unique keys, the
+// valid conflict columns of an upsert.
+var {{$alias.UpSingular}}ConflictTargets = [][]string{
+	{{$alias.DownSingular}}PrimaryKeyColumns,
+	{{- $targets}}
+}
+{{- end -}}
@@ -0,0 +1,127 @@
+{{- if not .Table.IsView -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $once := onceNew }}
+// Validate checks the {{$alias.UpSingular}} against the check constraints and enum
+// types of {{.Table.Name}} that can be verified in Go, so that invalid values are
+// reported before the database rejects the write.
+{{- range $col := .Table.Columns -}}
+	{{- range $line := splitLines $col.Comment -}}
+		{{- if and (gt (len $line) 11) (eq (slice $line 0 11) "unchecked: ") ($once.Put $line)}}
+//
+// Not checked: {{slice $line 11}}
+		{{- end -}}
+	{{- end -}}
+{{- end}}
+func (o *{{$alias.UpSingular}}) Validate() error {
+{{- range $col := .Table.Columns -}}
+{{- $typed := false -}}
+{{- range $line := splitLines $col.Comment -}}
+	{{- if and (gt (len $line) 8) (eq (slice $line 0 8) "go:type=") -}}{{- $typed = true -}}{{- end -}}
+{{- end -}}
+{{- /* The constraints don't tell how to compare the type of a go:type annotation */ -}}
+{{- if not $typed -}}
+	{{- $field := printf "o.%s" ($alias.Column $col.Name) -}}
+	{{- $nullType := and (gt (len $col.Type) 5) (eq (slice $col.Type 0 5) "null.") -}}
+	{{- $validType := or $nullType (eq $col.Type "CRDBNullUUID") (and (gt (len $col.Type) 14) (eq (slice $col.Type 0 14) "crdbtypes.Null")) -}}
+	{{- $value := $field -}}
+	{{- if $nullType -}}
+		{{- $value = printf "%s.%s" $field (slice $col.Type 5) -}}
+	{{- else if $col.Nullable -}}
+		{{- $value = printf "%s.Val" $field -}}
+	{{- end -}}
+	{{- $vals := parseEnumVals $col.DBType -}}
+	{{- $cases := "" -}}
+	{{- range $i, $val := $vals -}}
+		{{- if $i -}}{{- $cases = printf "%s, " $cases -}}{{- end -}}
+		{{- $cases = printf "%s%q" $cases $val -}}
+	{{- end -}}
+	{{- /* The enum-label lines list the labels the DBType can't, already quoted */ -}}
+	{{- $labels := "" -}}
+	{{- range $line := splitLines $col.Comment -}}
+		{{- if and (gt (len $line) 12) (eq (slice $line 0 12) "enum-label: ") -}}
+			{{- if $labels -}}{{- $labels = printf "%s, " $labels -}}{{- end -}}
+			{{- $labels = printf "%s%s" $labels (slice $line 12) -}}
+		{{- end -}}
+	{{- end -}}
+	{{- if $labels -}}
+		{{- $cases = $labels -}}
+	{{- end -}}
+	{{- if and $cases (not $col.ArrType)}}
+	{{if $col.Nullable}}if {{$field}}.Valid {
+	{{end -}}
+	switch string({{$value}}) {
+	case {{$cases}}:
+	default:
+		return fmt.Errorf("{{$.PkgName}}: {{$.Table.Name}}.{{$col.Name}} %q is not one of the allowed values", {{$value}})
+	}
+	{{- if $col.Nullable}}
+	}
+	{{- end -}}
+	{{- end -}}
+	{{- if and $col.FullDBType (eq $col.DBType "varchar" "char" "string" "character varying" "character")}}
+	if {{if $col.Nullable}}{{$field}}.Valid && {{end}}len([]rune({{$value}})) > {{$alias.UpSingular}}{{$alias.Column $col.Name}}MaxLength {
+		return fmt.Errorf("{{$.PkgName}}: {{$.Table.Name}}.{{$col.Name}} is longer than %d characters", {{$alias.UpSingular}}{{$alias.Column $col.Name}}MaxLength)
+	}
+	{{- end -}}
+	{{- range $line := splitLines $col.Comment -}}
+		{{- if and (gt (len $line) 7) (eq (slice $line 0 7) "check: ") -}}
+			{{- $rule := slice $line 7 -}}
+			{{- $err := printf "%s: %s.%s must satisfy %s" $.PkgName $.Table.Name $col.Name $rule | printf "%q" -}}
+			{{- $length := printf "length(%s) " $col.Name -}}
+			{{- $compare := printf "%s " $col.Name -}}
+			{{- $in := printf "%s IN " $col.Name -}}
+			{{- if eq $rule (printf "%s IS NOT NULL" $col.Name) -}}
+				{{- if $col.Nullable}}
+	if {{if or $validType (gt (len $vals) 0)}}!{{$field}}.Valid{{else if eq $col.Type "types.NullDecimal"}}{{$field}}.Big == nil{{else}}{{$field}} == nil{{end}} {
+		return errors.New({{$err}})
+	}
+				{{- end -}}
+			{{- else if and (gt (len $rule) (len $length)) (eq (slice $rule 0 (len $length)) $length)}}
+	if {{if $col.Nullable}}{{$field}}.Valid && {{end}}!(len([]rune({{$value}})) {{slice $rule (len $length)}}) {
+		return errors.New({{$err}})
+	}
+			{{- else if and (gt (len $rule) (len $in)) (eq (slice $rule 0 (len $in)) $in) -}}
+				{{- /* The values are listed like the labels of an enum DBType */ -}}
+				{{- $cases := "" -}}
+				{{- range $i, $val := parseEnumVals (printf "enum%s" (slice $rule (len $in))) -}}
+					{{- if $i -}}{{- $cases = printf "%s, " $cases -}}{{- end -}}
+					{{- $cases = printf "%s%q" $cases $val -}}
+				{{- end}}
+	{{if $col.Nullable}}if {{$field}}.Valid {
+	{{end -}}
+	switch string({{$value}}) {
+	case {{$cases}}:
+	default:
+		return errors.New({{$err}})
+	}
+	{{- if $col.Nullable}}
+	}
+	{{- end -}}
+			{{- else if and (eq $col.Type "types.Decimal" "types.NullDecimal") (gt (len $rule) (len $compare)) (eq (slice $rule 0 (len $compare)) $compare) -}}
+				{{- /* Decimals are compared with Cmp, as in "amount > 0" */ -}}
+				{{- $cmp := slice $rule (len $compare) -}}
+				{{- $op := "" -}}
+				{{- $num := "" -}}
+				{{- range $o := splitLines ">=\n<=\n!=\n==\n>\n<" -}}
+					{{- $p := printf "%s " $o -}}
+					{{- if and (eq $op "") (gt (len $cmp) (len $p)) (eq (slice $cmp 0 (len $p)) $p) -}}
+						{{- $op = $o -}}
+						{{- $num = slice $cmp (len $p) -}}
+					{{- end -}}
+				{{- end}}
+	if {{$field}}.Big != nil && !({{$field}}.Big.Cmp(crdbDecimal({{printf "%q" $num}})) {{$op}} 0) {
+		return errors.New({{$err}})
+	}
+			{{- else if and (gt (len $rule) (len $compare)) (eq (slice $rule 0 (len $compare)) $compare)}}
+	if {{if $col.Nullable}}{{$field}}.Valid && {{end}}!({{$value}} {{slice $rule (len $compare)}}) {
+		return errors.New({{$err}})
+	}
+			{{- end -}}
+		{{- end -}}
+	{{- end -}}
+{{- end -}}
+{{- end}}
+
+	return nil
+}
+{{- end -}}
@@ -0,0 +1,49 @@
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $hasLimits := false -}}
+{{- range $col := .Table.Columns -}}
+	{{- if and $col.FullDBType (eq $col.DBType "varchar" "char" "string" "character varying" "character" "decimal" "numeric" "bit" "varbit" "bit varying" "timestamp" "timestamptz" "time" "timetz" "interval") -}}
+		{{- $hasLimits = true -}}
+	{{- end -}}
+	{{- range $line := splitLines $col.Comment -}}
+		{{- if and (gt (len $line) 15) (eq (slice $line 0 15) "shard-buckets: ") -}}
+			{{- $hasLimits = true -}}
+		{{- end -}}
+	{{- end -}}
+{{- end -}}
+{{- if $hasLimits}}
+
+// Limits of the {{.Table.Name}} columns, from the length, precision and scale or
+// bit width of their types and the buckets of their hash-sharded indexes.
+const (
+{{- range $col := .Table.Columns -}}
+	{{- $name := printf "%s%s" $alias.UpSingular ($alias.Column $col.Name) -}}
+	{{- if and $col.FullDBType (lt (len $col.DBType) (len $col.FullDBType)) (eq (slice $col.FullDBType 0 (len $col.DBType)) $col.DBType) -}}
+		{{- /* varchar(1000) gives (1000) then 1000 */ -}}
+		{{- $mods := slice $col.FullDBType (len $col.DBType) -}}
+		{{- $mods = slice $mods 1 (len (slice $mods 1)) -}}
+		{{- if eq $col.DBType "varchar" "char" "string" "character varying" "character"}}
+	// {{$name}}MaxLength is the maximum length of {{$col.Name}}, in characters.
+	{{$name}}MaxLength = {{$mods}}
+		{{- else if eq $col.DBType "decimal" "numeric"}}
+	// {{$name}}Precision and {{$name}}Scale are the number of digits of
+	// {{$col.Name}}, and of its digits after the decimal point.
+	{{$name}}Precision, {{$name}}Scale = {{$mods}}
+		{{- else if eq $col.DBType "bit" "varbit" "bit varying"}}
+	// {{$name}}BitWidth is the number of bits of {{$col.Name}}.
+	{{$name}}BitWidth = {{$mods}}
+		{{- else if eq $col.DBType "timestamp" "timestamptz" "time" "timetz" "interval"}}
+	// {{$name}}Precision is the number of fractional digits of the seconds of
+	// {{$col.Name}}.
+	{{$name}}Precision = {{$mods}}
+		{{- end -}}
+	{{- end -}}
+	{{- range $line := splitLines $col.Comment -}}
+		{{- if and (gt (len $line) 15) (eq (slice $line 0 15) "shard-buckets: ")}}
+	// {{$name}}ShardBuckets is the number of buckets of the hash-sharded index
+	// on {{$col.Name}}.
+	{{$name}}ShardBuckets = {{slice $line 15}}
+		{{- end -}}
+	{{- end -}}
+{{- end}}
+)
+{{- end -}}
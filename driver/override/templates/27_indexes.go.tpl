@@ -0,0 +1,31 @@
+{{- if and (not .Table.IsView) .Table.Columns -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $fields := "" -}}
+{{- $values := "" -}}
+{{- $hint := "" -}}
+{{- $name := "" -}}
+{{- $seen := onceNew -}}
+{{- range $line := splitLines (index .Table.Columns 0).Comment -}}
+	{{- if and (gt (len $line) 7) (eq (slice $line 0 7) "index: ") -}}
+		{{- $name = slice $line 7 -}}
+	{{- else if and (gt (len $line) 11) (eq (slice $line 0 11) "index-def: ") (ne $name "") -}}
+		{{- $field := titleCase $name -}}
+		{{- if $seen.Put $field -}}
+			{{- $fields = printf "%s\n\t// %s is %s.\n\t%s string" $fields $field (slice $line 11) $field -}}
+			{{- $values = printf "%s\n\t%s: %q," $values $field $name -}}
+			{{- if eq $hint "" -}}
+				{{- $hint = $name -}}
+			{{- end -}}
+		{{- end -}}
+		{{- $name = "" -}}
+	{{- end -}}
+{{- end -}}
+{{- if $fields}}
+
+// {{$alias.UpSingular}}Indexes are the names of the indexes of {{.Table.Name}}, for index
+// hints like {{.Table.Name}}@{{$hint}}, with their definition.
+var {{$alias.UpSingular}}Indexes = struct { {{- $fields}}
+}{ {{- $values}}
+}
+{{- end -}}
+{{- end -}}
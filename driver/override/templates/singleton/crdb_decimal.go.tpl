@@ -0,0 +1,28 @@
+{{- /*
+The Validate template compares decimal columns with the constants of their
+check constraints, as in "check: amount > 0", through crdbDecimal. The file is
+empty without such a check, so it imports its package itself.
+*/ -}}
+{{- $used := false -}}
+{{- range $table := .Tables -}}
+	{{- range $col := $table.Columns -}}
+		{{- if eq $col.Type "types.Decimal" "types.NullDecimal" -}}
+			{{- range $line := splitLines $col.Comment -}}
+				{{- if and (gt (len $line) 7) (eq (slice $line 0 7) "check: ") -}}
+					{{- $used = true -}}
+				{{- end -}}
+			{{- end -}}
+		{{- end -}}
+	{{- end -}}
+{{- end -}}
+{{- if $used}}
+
+import "github.com/ericlagergren/decimal"
+
+// crdbDecimal returns the constant s of a check constraint as a decimal, to
+// compare decimal columns with.
+func crdbDecimal(s string) *decimal.Big {
+	d, _ := new(decimal.Big).SetString(s)
+	return d
+}
+{{- end -}}
@@ -0,0 +1,94 @@
+// Make sure the imports are used when there are no enum arrays.
+var _ driver.Valuer = types.StringArray(nil)
+
+{{- if .AddEnumTypes -}}
+{{- /* Enums that are used by scalar columns already get their type from boil_types */ -}}
+{{- $scalar := onceNew -}}
+{{- range $table := .Tables -}}
+	{{- range $col := $table.Columns | filterColumnsByEnum -}}
+		{{- $_ := $scalar.Put (parseEnumName $col.DBType) -}}
+	{{- end -}}
+{{- end -}}
+{{- $once := onceNew -}}
+{{- range $table := .Tables -}}
+	{{- range $col := $table.Columns -}}
+		{{- /* Enums with enum-label lines are missing labels in their DBType */ -}}
+		{{- $listed := true -}}
+		{{- range $line := splitLines $col.Comment -}}
+			{{- if and (gt (len $line) 6) (eq (slice $line 0 6) "enum: ") -}}
+				{{- $listed = false -}}
+			{{- end -}}
+		{{- end -}}
+		{{- if and $col.ArrType $listed -}}
+			{{- $name := parseEnumName $col.ArrType -}}
+			{{- $vals := parseEnumVals $col.ArrType -}}
+			{{- if and (ne (len $name) 0) (gt (len $vals) 0) (isEnumNormal $vals) ($once.Put $name) -}}
+				{{- $enumName := titleCase $name -}}
+				{{- $sliceName := printf "%sSlice" $enumName -}}
+				{{- if not ($scalar.Has $name)}}
+
+type {{$enumName}} string
+
+// Enum values for {{$enumName}}
+const (
+					{{- range $val := $vals}}
+						{{- $valStripped := stripWhitespace $val -}}
+						{{- $enumValue := $valStripped -}}
+						{{- if shouldTitleCaseEnum $valStripped -}}
+							{{- $enumValue = titleCase $valStripped -}}
+						{{- end}}
+	{{$enumName}}{{$enumValue}} {{$enumName}} = {{printf "%q" $val}}
+					{{- end}}
+)
+				{{- end}}
+
+// {{$sliceName}} is a slice of {{$enumName}} that supports SQL array serialization,
+// a nil slice is NULL.
+type {{$sliceName}} []{{$enumName}}
+
+// Scan implements the sql.Scanner interface.
+func (s *{{$sliceName}}) Scan(src interface{}) error {
+	var a types.StringArray
+	if err := a.Scan(src); err != nil {
+		return err
+	}
+	if a == nil {
+		*s = nil
+		return nil
+	}
+
+	*s = make({{$sliceName}}, len(a))
+	for i, v := range a {
+		(*s)[i] = {{$enumName}}(v)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (s {{$sliceName}}) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	a := make(types.StringArray, len(s))
+	for i, v := range s {
+		a[i] = string(v)
+	}
+	return a.Value()
+}
+
+// Randomize for sqlboiler, the column DBType holds the allowed values.
+func (s *{{$sliceName}}) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	var a types.StringArray
+	a.Randomize(nextInt, fieldType, shouldBeNull)
+
+	*s = make({{$sliceName}}, len(a))
+	for i, v := range a {
+		(*s)[i] = {{$enumName}}(v)
+	}
+}
+			{{- end -}}
+		{{- end -}}
+	{{- end -}}
+{{- end -}}
+{{- end -}}
@@ -0,0 +1,49 @@
+{{- /*
+sqlboiler only generates constants for enums whose labels can be turned into
+identifiers. The labels of the remaining named enums can still be quoted as Go
+string literals, so give them numbered constants in sort order instead of no
+constants at all. Enums with labels their DBType can't list come with an enum:
+line and an enum-label: line per label, already quoted, in the column comments.
+*/ -}}
+{{- $once := onceNew -}}
+{{- range $table := .Tables -}}
+	{{- range $col := $table.Columns -}}
+		{{- $name := "" -}}
+		{{- range $line := splitLines $col.Comment -}}
+			{{- if and (gt (len $line) 6) (eq (slice $line 0 6) "enum: ") -}}
+				{{- $name = slice $line 6 -}}
+			{{- end -}}
+		{{- end -}}
+		{{- if $name -}}
+			{{- if $once.Put $name -}}
+				{{- $enumName := titleCase $name -}}
+				{{- $n := ""}}
+
+// Enum values for {{$enumName}}, numbered in sort order because some of its
+// labels can't be listed in its DBType.
+const (
+				{{- range $line := splitLines $col.Comment -}}
+					{{- if and (gt (len $line) 12) (eq (slice $line 0 12) "enum-label: ")}}
+	{{$enumName}}Value{{len $n}} = {{slice $line 12}}
+						{{- $n = print $n "."}}
+					{{- end -}}
+				{{- end}}
+)
+			{{- end -}}
+		{{- else -}}
+			{{- $name = parseEnumName $col.DBType -}}
+			{{- $vals := parseEnumVals $col.DBType -}}
+			{{- if and (ne (len $name) 0) (gt (len $vals) 0) (not (isEnumNormal $vals)) ($once.Put $name) -}}
+				{{- $enumName := titleCase $name}}
+
+// Enum values for {{$enumName}}, numbered in sort order because its labels
+// can't be turned into identifiers.
+const (
+				{{- range $i, $val := $vals}}
+	{{$enumName}}Value{{$i}} = {{printf "%q" $val}}
+				{{- end}}
+)
+			{{- end -}}
+		{{- end -}}
+	{{- end -}}
+{{- end -}}
@@ -0,0 +1,123 @@
+{{- /*
+The regions of a multi-region database are the labels of its
+crdb_internal_region enum. They can't be turned into identifiers, so instead of
+constants they are listed in sort order, the numbered constants come from
+crdb_enums. Nullable region columns get a null type of their own, unless
+boil_types already generates one. The file is empty without region columns, so
+it imports its packages itself.
+*/ -}}
+{{- $dbType := "" -}}
+{{- $nullable := false -}}
+{{- range $table := .Tables -}}
+	{{- range $col := $table.Columns | filterColumnsByEnum -}}
+		{{- if eq (parseEnumName $col.DBType) "crdb_internal_region" -}}
+			{{- if not $dbType -}}
+				{{- $dbType = $col.DBType -}}
+			{{- end -}}
+			{{- if $col.Nullable -}}
+				{{- $nullable = true -}}
+			{{- end -}}
+		{{- end -}}
+	{{- end -}}
+{{- end -}}
+{{- if $dbType -}}
+{{- $vals := parseEnumVals $dbType -}}
+{{- $enumName := titleCase "crdb_internal_region" -}}
+{{- $nullName := print $.EnumNullPrefix $enumName -}}
+{{- $own := not (and $.AddEnumTypes (isEnumNormal $vals)) -}}
+{{- if and $nullable $own}}
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+{{- end}}
+{{- if $own}}
+
+// {{$enumName}} is a region of the multi-region database.
+type {{$enumName}} string
+{{- end}}
+
+// {{$enumName}}s are the regions of the database, in sort order.
+var {{$enumName}}s = []{{$enumName}}{
+	{{- range $val := $vals}}
+	{{printf "%q" $val}},
+	{{- end}}
+}
+
+// IsRegion tells whether r is one of the regions of the database.
+func (r {{$enumName}}) IsRegion() bool {
+	for _, region := range {{$enumName}}s {
+		if r == region {
+			return true
+		}
+	}
+
+	return false
+}
+{{- if and $nullable $own}}
+
+// {{$nullName}} is a region of the multi-region database that may be NULL.
+type {{$nullName}} struct {
+	Val   {{$enumName}}
+	Valid bool
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *{{$nullName}}) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		*n = {{$nullName}}{}
+	case string:
+		*n = {{$nullName}}{Val: {{$enumName}}(src), Valid: true}
+	case []byte:
+		*n = {{$nullName}}{Val: {{$enumName}}(src), Valid: true}
+	default:
+		return fmt.Errorf("cannot scan %T into {{$nullName}}", src)
+	}
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (n {{$nullName}}) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return string(n.Val), nil
+}
+
+// MarshalJSON implements json.Marshaler, a NULL region is null.
+func (n {{$nullName}}) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(string(n.Val))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *{{$nullName}}) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = {{$nullName}}{}
+		return nil
+	}
+
+	n.Valid = true
+	return json.Unmarshal(data, &n.Val)
+}
+
+// Randomize implements randomize.Randomizer with one of the regions.
+func (n *{{$nullName}}) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull || len({{$enumName}}s) == 0 {
+		*n = {{$nullName}}{}
+		return
+	}
+	i := nextInt() % int64(len({{$enumName}}s))
+	if i < 0 {
+		i = -i
+	}
+	*n = {{$nullName}}{Val: {{$enumName}}s[i], Valid: true}
+}
+{{- end}}
+{{- end -}}
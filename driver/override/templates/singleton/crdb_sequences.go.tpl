@@ -0,0 +1,90 @@
+{{- /*
+Sequences reach the templates as the "schema-sequence: " lines of the comment
+of the first column of each table of their schema, and as the "sequence: " line
+of the comment of the columns whose default takes their next value. The generic
+helpers are always generated, they are what the typed ones call. The imports of
+this file are static, so context is imported here, when it's used.
+*/ -}}
+{{- $ctxArg := "ctx context.Context, exec boil.ContextExecutor" -}}
+{{- $ctx := "ctx, " -}}
+{{- $suffix := "Context" -}}
+{{- if .NoContext -}}
+	{{- $ctxArg = "exec boil.Executor" -}}
+	{{- $ctx = "" -}}
+	{{- $suffix = "" -}}
+{{- end -}}
+{{- if not .NoContext}}
+
+import "context"
+{{- end}}
+
+// NextSequenceVal advances the sequence name and returns its new value, like
+// nextval(name).
+func NextSequenceVal({{$ctxArg}}, name string) (int64, error) {
+	var value int64
+	err := exec.QueryRow{{$suffix}}({{$ctx}}"SELECT nextval($1::REGCLASS)", strmangle.IdentQuote('"', '"', name)).Scan(&value)
+	if err != nil {
+		return 0, errors.Wrapf(err, "{{.PkgName}}: unable to get the next value of sequence %s", name)
+	}
+
+	return value, nil
+}
+
+// CurrentSequenceVal returns the last value the sequence name handed out, in
+// any session. Unlike currval(name), it doesn't need the connection to have
+// called nextval(name) first.
+func CurrentSequenceVal({{$ctxArg}}, name string) (int64, error) {
+	var value int64
+	query := fmt.Sprintf("SELECT last_value FROM %s", strmangle.IdentQuote('"', '"', name))
+	if err := exec.QueryRow{{$suffix}}({{$ctx}}query).Scan(&value); err != nil {
+		return 0, errors.Wrapf(err, "{{.PkgName}}: unable to get the current value of sequence %s", name)
+	}
+
+	return value, nil
+}
+
+// SetSequenceVal sets the current value of the sequence name, the next value
+// it hands out is the one after value, like setval(name, value).
+func SetSequenceVal({{$ctxArg}}, name string, value int64) error {
+	_, err := exec.Exec{{$suffix}}({{$ctx}}"SELECT setval($1::REGCLASS, $2)", strmangle.IdentQuote('"', '"', name), value)
+	if err != nil {
+		return errors.Wrapf(err, "{{.PkgName}}: unable to set the value of sequence %s", name)
+	}
+
+	return nil
+}
+{{- $once := onceNew -}}
+{{- range $table := .Tables -}}
+	{{- range $col := $table.Columns -}}
+		{{- range $line := splitLines $col.Comment -}}
+			{{- $seq := "" -}}
+			{{- if and (gt (len $line) 10) (eq (slice $line 0 10) "sequence: ") -}}
+				{{- $seq = slice $line 10 -}}
+			{{- else if and (gt (len $line) 17) (eq (slice $line 0 17) "schema-sequence: ") -}}
+				{{- $seq = slice $line 17 -}}
+			{{- end -}}
+			{{- if and $seq ($once.Put $seq) -}}
+				{{- $name := $seq | goVarname | titleCase -}}
+				{{- $qualified := $seq -}}
+				{{- if $.Dialect.UseSchema -}}
+					{{- $qualified = printf "%s.%s" $.Schema $seq -}}
+				{{- end}}
+
+// Next{{$name}} advances the {{$seq}} sequence and returns its new value.
+func Next{{$name}}({{$ctxArg}}) (int64, error) {
+	return NextSequenceVal({{$ctx}}exec, "{{$qualified}}")
+}
+
+// CurrentVal{{$name}} returns the last value the {{$seq}} sequence handed out.
+func CurrentVal{{$name}}({{$ctxArg}}) (int64, error) {
+	return CurrentSequenceVal({{$ctx}}exec, "{{$qualified}}")
+}
+
+// SetVal{{$name}} sets the current value of the {{$seq}} sequence.
+func SetVal{{$name}}({{$ctxArg}}, value int64) error {
+	return SetSequenceVal({{$ctx}}exec, "{{$qualified}}", value)
+}
+			{{- end -}}
+		{{- end -}}
+	{{- end -}}
+{{- end -}}
@@ -0,0 +1,115 @@
+{{- /*
+UUID columns are only mapped to these types with the uuid option, which adds a
+"go:uuid=" line with the import path of the UUID package to their comment.
+randomize can't fill the [16]byte of a UUID, so the plain and nullable types
+wrap the ones of the package with a Randomize method. The CRDB prefix keeps them
+apart from the model of a table named uuid. The file is empty without UUID
+columns, so it imports its packages itself.
+*/ -}}
+{{- $pkg := "" -}}
+{{- range $table := .Tables -}}
+	{{- range $col := $table.Columns -}}
+		{{- range $line := splitLines $col.Comment -}}
+			{{- if and (gt (len $line) 8) (eq (slice $line 0 8) "go:uuid=") -}}
+				{{- $pkg = slice $line 8 -}}
+			{{- end -}}
+		{{- end -}}
+	{{- end -}}
+{{- end -}}
+{{- if $pkg}}
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+
+	uuid "{{$pkg}}"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// CRDBUUID is a UUID column, a uuid.UUID the generated tests can randomize.
+type CRDBUUID struct {
+	uuid.UUID
+}
+
+// Randomize implements randomize.Randomizer.
+func (u *CRDBUUID) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*u = CRDBUUID{}
+		return
+	}
+	u.UUID = crdbRandomUUID(nextInt)
+}
+
+// CRDBNullUUID is a nullable UUID column, a uuid.NullUUID the generated tests
+// can randomize.
+type CRDBNullUUID struct {
+	uuid.NullUUID
+}
+
+// Randomize implements randomize.Randomizer.
+func (u *CRDBNullUUID) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*u = CRDBNullUUID{}
+		return
+	}
+	u.UUID = crdbRandomUUID(nextInt)
+	u.Valid = true
+}
+
+// CRDBUUIDArray is a UUID[] column, a nil slice is NULL.
+type CRDBUUIDArray []uuid.UUID
+
+// Scan implements the sql.Scanner interface.
+func (a *CRDBUUIDArray) Scan(src interface{}) error {
+	var strs types.StringArray
+	if err := strs.Scan(src); err != nil {
+		return err
+	}
+	if strs == nil {
+		*a = nil
+		return nil
+	}
+
+	uuids := make(CRDBUUIDArray, len(strs))
+	for i, s := range strs {
+		if err := uuids[i].UnmarshalText([]byte(s)); err != nil {
+			return err
+		}
+	}
+	*a = uuids
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (a CRDBUUIDArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+
+	strs := make(types.StringArray, len(a))
+	for i, u := range a {
+		strs[i] = u.String()
+	}
+	return strs.Value()
+}
+
+// Randomize implements randomize.Randomizer.
+func (a *CRDBUUIDArray) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*a = nil
+		return
+	}
+	*a = CRDBUUIDArray{crdbRandomUUID(nextInt)}
+}
+
+// crdbRandomUUID returns a version 4 UUID made of the next ints of the seed, so
+// that the UUIDs of a test run are unique.
+func crdbRandomUUID(nextInt func() int64) uuid.UUID {
+	var u uuid.UUID
+	binary.BigEndian.PutUint64(u[:8], uint64(nextInt()))
+	binary.BigEndian.PutUint64(u[8:], uint64(nextInt()))
+	u[6] = u[6]&0x0f | 0x40 // version 4
+	u[8] = u[8]&0x3f | 0x80 // RFC 4122 variant
+	return u
+}
+{{- end -}}
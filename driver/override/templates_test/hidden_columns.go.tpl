@@ -0,0 +1,88 @@
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $hidden := false -}}
+{{- range $col := .Table.Columns -}}
+	{{- range $line := splitLines $col.Comment -}}
+		{{- if eq $line "hidden: true" -}}
+			{{- $hidden = true -}}
+		{{- end -}}
+	{{- end -}}
+{{- end -}}
+{{- if and $hidden (not .Table.IsView) .Table.PKey -}}
+{{- $pkArgs := .Table.PKey.Columns | stringMap (aliasCols $alias) | prefixStringSlice "o." | join ", " }}
+func test{{$alias.UpPlural}}HiddenColumns(t *testing.T) {
+	t.Parallel()
+
+	if len({{$alias.DownSingular}}AllColumns) == len({{$alias.DownSingular}}PrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &{{$alias.UpSingular}}{}
+	if err = randomize.Struct(seed, o, {{$alias.DownSingular}}DBTypes, true, {{$alias.DownSingular}}ColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize {{$alias.UpSingular}} struct: %s", err)
+	}
+
+	{{if not .NoContext}}ctx := context.Background(){{end}}
+	tx := MustTx({{if .NoContext}}boil.Begin(){{else}}boil.BeginTx(ctx, nil){{end}})
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert({{if not .NoContext}}ctx, {{end -}} tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	// The hidden columns are left out of SELECT *, they have to be loaded
+	// for the primary key of updates and the columns they write.
+	found, err := Find{{$alias.UpSingular}}({{if not .NoContext}}ctx, {{end -}} tx, {{$pkArgs}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	{{- range $col := .Table.Columns -}}
+	{{- range $line := splitLines $col.Comment -}}
+	{{- if eq $line "hidden: true" -}}
+	{{- $colAlias := $alias.Column $col.Name}}
+	if !reflect.DeepEqual(found.{{$colAlias}}, o.{{$colAlias}}) {
+		t.Errorf("want {{$col.Name}} %v, got %v", o.{{$colAlias}}, found.{{$colAlias}})
+	}
+	{{- end -}}
+	{{- end -}}
+	{{- end}}
+
+	{{if .NoRowsAffected -}}
+	if err = found.Update({{if not .NoContext}}ctx, {{end -}} tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	{{- else -}}
+	rowsAff, err := found.Update({{if not .NoContext}}ctx, {{end -}} tx, boil.Infer())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+	{{- end}}
+
+	if err = found.Reload({{if not .NoContext}}ctx, {{end -}} tx); err != nil {
+		t.Fatal(err)
+	}
+	all, err := {{$alias.UpPlural}}().All({{if not .NoContext}}ctx, {{end -}} tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 {
+		t.Fatal("want one record, got:", len(all))
+	}
+	if err = all.ReloadAll({{if not .NoContext}}ctx, {{end -}} tx); err != nil {
+		t.Fatal(err)
+	}
+	{{- range $col := .Table.Columns -}}
+	{{- range $line := splitLines $col.Comment -}}
+	{{- if eq $line "hidden: true" -}}
+	{{- $colAlias := $alias.Column $col.Name}}
+	if reflect.ValueOf(found.{{$colAlias}}).IsZero() || reflect.ValueOf(all[0].{{$colAlias}}).IsZero() {
+		t.Error("want {{$col.Name}} read back, got the zero value")
+	}
+	{{- end -}}
+	{{- end -}}
+	{{- end}}
+}
+{{end -}}
@@ -72,6 +72,50 @@ func (c *crdbTester) setup() error {
       return errors.Wrap(err, "failed to wait for 'cockroach sql' command")
   }
 
+  if err = c.pauseRowLevelTTL(); err != nil {
+      return errors.Wrap(err, "failed to pause row-level TTL")
+  }
+
+  return nil
+}
+
+// pauseRowLevelTTL pauses the row-level TTL jobs of the test database, the
+// tests assume the rows they write persist while rows of tables with a TTL
+// may be deleted at any time.
+func (c *crdbTester) pauseRowLevelTTL() error {
+  db, err := sql.Open("postgres", c.testDBURL)
+  if err != nil {
+    return err
+  }
+  defer db.Close()
+
+  rows, err := db.Query(`SELECT pgn.nspname, pgc.relname
+    FROM pg_class AS pgc INNER JOIN pg_namespace AS pgn ON pgc.relnamespace = pgn.oid
+    WHERE pgc.relkind = 'r' AND array_to_string(pgc.reloptions, ',') LIKE '%ttl_expir%'`)
+  if err != nil {
+    return err
+  }
+
+  var tables []string
+  for rows.Next() {
+    var schema, table string
+    if err = rows.Scan(&schema, &table); err != nil {
+      rows.Close()
+      return err
+    }
+    tables = append(tables, pq.QuoteIdentifier(schema)+"."+pq.QuoteIdentifier(table))
+  }
+  rows.Close()
+  if err = rows.Err(); err != nil {
+    return err
+  }
+
+  for _, table := range tables {
+    if _, err = db.Exec(fmt.Sprintf("ALTER TABLE %s SET (ttl_pause = true)", table)); err != nil {
+      return err
+    }
+  }
+
   return nil
 }
 
@@ -127,8 +171,15 @@ func (c *crdbTester) conn() (*sql.DB, error) {
     return c.dbConn, nil
   }
 
+  // Tables of several schemas are generated unqualified, resolve them
+  // through the search_path like the application has to.
+  url := c.testDBURL
+  if schemas := viper.GetStringSlice("crdb.schemas"); len(schemas) != 0 {
+    url += "&search_path=" + strings.Join(schemas, ",")
+  }
+
   var err error
-  c.dbConn, err = sql.Open("postgres", c.testDBURL)
+  c.dbConn, err = sql.Open("postgres", url)
   if err != nil {
     return nil, err
   }
@@ -6,4 +6,22 @@ func TestUpsert(t *testing.T) {
   t.Run("{{$tableName}}", test{{$tableName}}Upsert)
   {{end -}}
   {{- end -}}
-}
\ No newline at end of file
+}
+
+func TestHiddenColumns(t *testing.T) {
+  {{- range $index, $table := .Tables}}
+  {{- $hidden := false -}}
+  {{- range $col := $table.Columns -}}
+    {{- range $line := splitLines $col.Comment -}}
+      {{- if eq $line "hidden: true" -}}
+        {{- $hidden = true -}}
+      {{- end -}}
+    {{- end -}}
+  {{- end -}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) (not $hidden) -}}
+  {{- else -}}
+  {{- $tableName := $table.Name | plural | titleCase -}}
+  t.Run("{{$tableName}}", test{{$tableName}}HiddenColumns)
+  {{end -}}
+  {{- end -}}
+}
@@ -45,4 +45,4 @@ func test{{$alias.UpPlural}}Upsert(t *testing.T) {
 	if count != 1 {
 		t.Error("want one record, got:", count)
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,44 @@
+package driver
+
+import "github.com/pkg/errors"
+
+// searchSchemas returns the schemas tables are listed from.
+func (d *CockroachDBDriver) searchSchemas(schema string) []string {
+	if len(d.schemas) > 0 {
+		return d.schemas
+	}
+	return []string{schema}
+}
+
+// addTableSchemas records the schema each table or view was listed from and
+// returns their names. Names are left unqualified in the generated code and
+// resolved through the search_path, so a name may only exist in one of the
+// generated schemas. Tables are listed before views, the name of a view is
+// checked against the tables too.
+func (d *CockroachDBDriver) addTableSchemas(keys []tableKey) ([]string, error) {
+	names := make([]string, 0, len(keys))
+	if d.tableSchemas == nil {
+		for _, key := range keys {
+			names = append(names, key.name)
+		}
+		return names, nil
+	}
+
+	for _, key := range keys {
+		if other, ok := d.tableSchemas[key.name]; ok {
+			return nil, errors.Errorf("sqlboiler-crdb: %s is in both schemas %s and %s, the models would share the name; generate one of them in a run of its own, with schema=%s", key.name, other, key.schema, key.schema)
+		}
+		d.tableSchemas[key.name] = key.schema
+		names = append(names, key.name)
+	}
+
+	return names, nil
+}
+
+// tableSchema returns the schema tableName was listed from, or schema.
+func (d *CockroachDBDriver) tableSchema(schema, tableName string) string {
+	if s, ok := d.tableSchemas[tableName]; ok {
+		return s
+	}
+	return schema
+}
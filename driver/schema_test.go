@@ -0,0 +1,57 @@
+package driver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAddTableSchemas(t *testing.T) {
+	t.Parallel()
+
+	d := &CockroachDBDriver{schemas: []string{"public", "billing"}, tableSchemas: make(map[string]string)}
+	names, err := d.addTableSchemas([]tableKey{{"billing", "invoices"}, {"public", "users"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"invoices", "users"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("want %v, got %v", want, names)
+	}
+	if schema := d.tableSchema("public", "invoices"); schema != "billing" {
+		t.Errorf("want invoices from billing, got %s", schema)
+	}
+
+	// A name in several schemas would give two models the same name, views
+	// are listed after the tables
+	if _, err := d.addTableSchemas([]tableKey{{"billing", "users"}}); err == nil {
+		t.Error("want an error for users in both schemas")
+	}
+	if _, err := d.addTableSchemas([]tableKey{{"public", "invoices"}}); err == nil {
+		t.Error("want an error for the invoices view")
+	}
+}
+
+func TestForeignKeyInfoSchemas(t *testing.T) {
+	t.Parallel()
+
+	key := tableKey{"billing", "invoices"}
+	d := &CockroachDBDriver{
+		schemas:      []string{"public", "billing"},
+		tableSchemas: map[string]string{"invoices": "billing", "users": "public"},
+		catalog: &catalog{foreignKeys: map[tableKey][]foreignKeyColumn{key: {
+			{name: "invoices_user_id_fkey", column: "user_id", foreignSchema: "public", foreignTable: "users", foreignColumn: "id", columnCount: 1},
+			{name: "invoices_auditor_id_fkey", column: "auditor_id", foreignSchema: "audit", foreignTable: "users", foreignColumn: "id", columnCount: 1},
+			{name: "invoices_payer_id_fkey", column: "payer_id", foreignSchema: "billing", foreignTable: "users", foreignColumn: "id", columnCount: 1},
+		}}},
+	}
+
+	fkeys, err := d.ForeignKeyInfo("public", "invoices")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The users model is public.users, the keys into audit.users and
+	// billing.users are skipped
+	if len(fkeys) != 1 || fkeys[0].Name != "invoices_user_id_fkey" {
+		t.Errorf("want invoices_user_id_fkey only, got %v", fkeys)
+	}
+}
@@ -0,0 +1,95 @@
+package driver
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// rgxNextval matches the default of a column taking the next value of a
+// sequence, as in nextval('public.invoice_number_seq'::REGCLASS).
+var rgxNextval = regexp.MustCompile(`^nextval\('((?:[^']|'')+)'(?::::?[A-Za-z]+)?\)$`)
+
+// loadSequences lists the sequences of the schemas.
+func (d *CockroachDBDriver) loadSequences(cat *catalog, schemas []string) error {
+	if !d.caps.sequences {
+		return nil
+	}
+
+	query := `SELECT
+	s.sequence_schema,
+	s.sequence_name
+FROM
+	information_schema.sequences AS s
+WHERE
+	s.sequence_schema = ANY($1)
+ORDER BY
+	s.sequence_schema, s.sequence_name ASC;`
+
+	rows, err := d.conn.Query(query, pq.Array(schemas))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key tableKey
+		if err := rows.Scan(&key.schema, &key.name); err != nil {
+			return errors.Wrap(err, "unable to scan sequences")
+		}
+		cat.sequences = append(cat.sequences, key)
+	}
+
+	return rows.Err()
+}
+
+// parseNextval returns the sequence a column default takes the next value of,
+// its schema being empty when the sequence name isn't qualified.
+func parseNextval(defaultValue string) (tableKey, bool) {
+	m := rgxNextval.FindStringSubmatch(defaultValue)
+	if m == nil {
+		return tableKey{}, false
+	}
+
+	name := strings.Replace(m[1], "''", "'", -1)
+	parts := splitTopLevel(name, ".")
+	for i, part := range parts {
+		parts[i] = unquoteIdent(part)
+	}
+
+	switch len(parts) {
+	case 1:
+		return tableKey{name: parts[0]}, true
+	case 2:
+		return tableKey{schema: parts[0], name: parts[1]}, true
+	case 3: // database.schema.sequence
+		return tableKey{schema: parts[1], name: parts[2]}, true
+	}
+	return tableKey{}, false
+}
+
+// columnSequence returns the name of the sequence of a generated schema the
+// default of a column of the table key takes the next value of. Sequence
+// names are left unqualified, like table names.
+func (cat *catalog) columnSequence(key tableKey, defaultValue *string) (string, bool) {
+	if defaultValue == nil {
+		return "", false
+	}
+
+	seq, ok := parseNextval(*defaultValue)
+	if !ok {
+		return "", false
+	}
+	if seq.schema == "" {
+		seq.schema = key.schema
+	}
+
+	for _, s := range cat.sequences {
+		if s == seq {
+			return seq.name, true
+		}
+	}
+	return "", false
+}
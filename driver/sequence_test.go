@@ -0,0 +1,59 @@
+package driver
+
+import (
+	"testing"
+)
+
+func TestParseNextval(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		def  string
+		want tableKey
+		ok   bool
+	}{
+		{"nextval('public.invoice_number_seq'::REGCLASS)", tableKey{"public", "invoice_number_seq"}, true},
+		{"nextval('invoice_number_seq':::STRING)", tableKey{"", "invoice_number_seq"}, true},
+		{"nextval('mydb.billing.invoice_number_seq'::REGCLASS)", tableKey{"billing", "invoice_number_seq"}, true},
+		{`nextval('public."Invoice Seq"'::REGCLASS)`, tableKey{"public", "Invoice Seq"}, true},
+		{"unique_rowid()", tableKey{}, false},
+		{"nextval('a.b.c.d'::REGCLASS)", tableKey{}, false},
+	}
+
+	for _, test := range tests {
+		got, ok := parseNextval(test.def)
+		if ok != test.ok || got != test.want {
+			t.Errorf("%s: want %v %t, got %v %t", test.def, test.want, test.ok, got, ok)
+		}
+	}
+}
+
+func TestColumnSequence(t *testing.T) {
+	t.Parallel()
+
+	cat := &catalog{sequences: []tableKey{{"public", "invoice_number_seq"}, {"billing", "credit_note_seq"}}}
+	key := tableKey{"public", "invoices"}
+	def := func(s string) *string { return &s }
+
+	tests := []struct {
+		def  *string
+		want string
+		ok   bool
+	}{
+		{def("nextval('public.invoice_number_seq'::REGCLASS)"), "invoice_number_seq", true},
+		{def("nextval('invoice_number_seq':::STRING)"), "invoice_number_seq", true},
+		{def("nextval('billing.credit_note_seq'::REGCLASS)"), "credit_note_seq", true},
+		// Sequences of schemas that aren't generated are left out
+		{def("nextval('audit.event_seq'::REGCLASS)"), "", false},
+		{def("nextval('credit_note_seq'::REGCLASS)"), "", false},
+		{def("unique_rowid()"), "", false},
+		{nil, "", false},
+	}
+
+	for _, test := range tests {
+		got, ok := cat.columnSequence(key, test.def)
+		if ok != test.ok || got != test.want {
+			t.Errorf("%v: want %q %t, got %q %t", test.def, test.want, test.ok, got, ok)
+		}
+	}
+}
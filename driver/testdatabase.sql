@@ -24,6 +24,8 @@ create table posts (
 	foreign key (user_id) references users (id)
 );
 
+comment on column posts.content is 'The body of the post, in Markdown.';
+
 CREATE TABLE comments (
 	user_id int null,
 	post_id int null,
@@ -0,0 +1,276 @@
+package driver
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/strmangle"
+)
+
+// typeModifiers returns dbType with the length, precision and scale or bit
+// width of dataType, as in varchar(1000) or decimal(2,1), and an empty string
+// when dataType has none. Decimals always get a scale, 0 when it's left out.
+func typeModifiers(dbType, dataType string) string {
+	full := strings.ToLower(dataType)
+	m := re.FindStringSubmatch(full)
+	if m == nil || full != dbType+m[0] {
+		return ""
+	}
+
+	mods := strings.Replace(m[1], " ", "", -1)
+	if (dbType == "decimal" || dbType == "numeric") && !strings.Contains(mods, ",") {
+		mods += ",0"
+	}
+
+	return fmt.Sprintf("%s(%s)", dbType, mods)
+}
+
+// TranslateColumnType converts Cockroach database types to Go types, for example
+// "varchar" to "string" and "bigint" to "int64". It returns this parsed data
+// as a Column object.
+func (d *CockroachDBDriver) TranslateColumnType(c drivers.Column) drivers.Column {
+	// parse DB type
+	if c.Nullable {
+		switch c.DBType {
+		case "int8", "bigint", "bigserial":
+			c.Type = "null.Int64"
+		case "int4", "int", "integer", "serial":
+			c.Type = "null.Int"
+		case "int2", "smallint", "smallserial":
+			c.Type = "null.Int16"
+		case "decimal", "numeric":
+			c.Type = "types.NullDecimal"
+		case "float8", "float", "double precision":
+			c.Type = "null.Float64"
+		case "real":
+			c.Type = "null.Float32"
+		case "string", "collate", "bit", "bit varying", "character", "character varying", "char", "varchar", "text":
+			c.Type = "null.String"
+		case "interval":
+			c.Type = "crdbtypes.NullInterval"
+		case "inet":
+			c.Type = "null.String"
+			if d.inetNetip {
+				c.Type = "crdbtypes.NullInet"
+			}
+		case "uuid":
+			c.Type = "null.String"
+			if d.uuidPackage != "" {
+				c.Type = "CRDBNullUUID"
+			}
+		case `"char"`:
+			c.Type = "null.Byte"
+		case "bytes", "bytea":
+			c.Type = "null.Bytes"
+		case "json", "jsonb":
+			c.Type = "null.JSON"
+		case "bool", "boolean":
+			c.Type = "null.Bool"
+		case "date":
+			c.Type = "null.Time"
+			if d.civilTime {
+				c.Type = "crdbtypes.NullDate"
+			}
+		case "time", "time without time zone":
+			c.Type = "null.Time"
+			if d.civilTime {
+				c.Type = "crdbtypes.NullTimeOfDay"
+			} else if c.DBType != "time" {
+				fmt.Fprintf(os.Stderr, "Warning: Unhandled nullable data type %s, falling back to null.String\n", c.DBType)
+				c.Type = "null.String"
+			}
+		case "timetz", "time with time zone":
+			c.Type = "null.String"
+			if d.civilTime {
+				c.Type = "crdbtypes.NullTimeOfDayTZ"
+			} else {
+				fmt.Fprintf(os.Stderr, "Warning: Unhandled nullable data type %s, falling back to null.String\n", c.DBType)
+			}
+		case "timestamp", "timestamp without time zone", "timestamptz", "timestamp with time zone":
+			c.Type = "null.Time"
+		case "geometry", "geography":
+			c.Type = "crdbtypes.NullGeometry"
+			c.DBType = spatialDBType(c)
+		case "array", "ARRAY":
+			if c.ArrType == nil {
+				panic("unable to get CockroachDB ARRAY underlying type")
+			}
+			c.Type = d.getArrayType(c)
+			// Make DBType something like ARRAYinteger for parsing with randomize.Struct
+			c.DBType = strings.ToUpper(c.DBType) + *c.ArrType
+		default:
+			if enumName := strmangle.ParseEnumName(c.DBType); enumName != "" {
+				if enumName == regionEnum {
+					// Generated by the crdb_regions singleton from the database's regions
+					c.Type = d.enumNullPrefix + strmangle.TitleCase(enumName)
+				} else if d.addEnumTypes && strmangle.IsEnumNormal(strmangle.ParseEnumVals(c.DBType)) && !hasEnumLabelLines(c.Comment) {
+					c.Type = d.enumNullPrefix + strmangle.TitleCase(enumName)
+				} else {
+					c.Type = "null.String"
+				}
+			} else {
+				fmt.Fprintf(os.Stderr, "Warning: Unhandled nullable data type %s, falling back to null.String\n", c.DBType)
+				c.Type = "null.String"
+			}
+		}
+	} else {
+		switch c.DBType {
+		case "int8", "bigint", "bigserial":
+			c.Type = "int64"
+		case "int4", "int", "integer", "serial":
+			c.Type = "int"
+		case "int2", "smallint", "smallserial":
+			c.Type = "int16"
+		case "decimal", "numeric":
+			c.Type = "types.Decimal"
+		case "float8", "float", "double precision":
+			c.Type = "float64"
+		case "real":
+			c.Type = "float32"
+		case "string", "collate", "bit", "bit varying", "character", "character varying", "char", "varchar", "text":
+			c.Type = "string"
+		case "interval":
+			c.Type = "crdbtypes.Interval"
+		case "inet":
+			c.Type = "string"
+			if d.inetNetip {
+				c.Type = "crdbtypes.Inet"
+			}
+		case "uuid":
+			c.Type = "string"
+			if d.uuidPackage != "" {
+				c.Type = "CRDBUUID"
+			}
+		case `"char"`:
+			c.Type = "types.Byte"
+		case "bytes", "bytea":
+			c.Type = "[]byte"
+		case "json", "jsonb":
+			c.Type = "types.JSON"
+		case "bool", "boolean":
+			c.Type = "bool"
+		case "date":
+			c.Type = "time.Time"
+			if d.civilTime {
+				c.Type = "crdbtypes.Date"
+			}
+		case "time", "time without time zone":
+			c.Type = "time.Time"
+			if d.civilTime {
+				c.Type = "crdbtypes.TimeOfDay"
+			} else if c.DBType != "time" {
+				fmt.Fprintf(os.Stderr, "Warning: Unhandled data type %s, falling back to string\n", c.DBType)
+				c.Type = "string"
+			}
+		case "timetz", "time with time zone":
+			c.Type = "string"
+			if d.civilTime {
+				c.Type = "crdbtypes.TimeOfDayTZ"
+			} else {
+				fmt.Fprintf(os.Stderr, "Warning: Unhandled data type %s, falling back to string\n", c.DBType)
+			}
+		case "timestamp", "timestamp without time zone", "timestamptz", "timestamp with time zone":
+			c.Type = "time.Time"
+		case "geometry", "geography":
+			c.Type = "crdbtypes.Geometry"
+			c.DBType = spatialDBType(c)
+		case "array", "ARRAY":
+			if c.ArrType == nil {
+				panic("unable to get CockroachDB ARRAY underlying type")
+			}
+			c.Type = d.getArrayType(c)
+			// Make DBType something like ARRAYinteger for parsing with randomize.Struct
+			c.DBType = strings.ToUpper(c.DBType) + *c.ArrType
+		default:
+			if enumName := strmangle.ParseEnumName(c.DBType); enumName != "" {
+				if enumName == regionEnum {
+					// Generated by the crdb_regions singleton from the database's regions
+					c.Type = strmangle.TitleCase(enumName)
+				} else if d.addEnumTypes && strmangle.IsEnumNormal(strmangle.ParseEnumVals(c.DBType)) && !hasEnumLabelLines(c.Comment) {
+					c.Type = strmangle.TitleCase(enumName)
+				} else {
+					c.Type = "string"
+				}
+			} else {
+				fmt.Fprintf(os.Stderr, "Warning: Unhandled data type %s, falling back to string\n", c.DBType)
+				c.Type = "string"
+			}
+		}
+	}
+
+	// The crdb_uuid singleton wraps the types of the package of this line
+	if d.uuidPackage != "" && (c.DBType == "uuid" || c.ArrType != nil && *c.ArrType == "uuid") {
+		c.Comment = strings.TrimPrefix(c.Comment+"\ngo:uuid="+d.uuidPackage, "\n")
+	}
+
+	return applyAnnotations(c)
+}
+
+// spatialDBType returns the DBType of a GEOMETRY or GEOGRAPHY column with
+// its shape and SRID, as in geography(point,4326), for Randomize to generate
+// geometries the column accepts.
+func spatialDBType(c drivers.Column) string {
+	if c.FullDBType != "" {
+		return c.FullDBType
+	}
+	return c.DBType
+}
+
+// getArrayType returns the correct boil.Array type for each database type.
+// Arrays of enums map to the generated enum slice type when enum types are
+// added, and to types.StringArray otherwise.
+func (d *CockroachDBDriver) getArrayType(c drivers.Column) string {
+	if enumName := strmangle.ParseEnumName(*c.ArrType); enumName != "" {
+		if d.addEnumTypes && strmangle.IsEnumNormal(strmangle.ParseEnumVals(*c.ArrType)) && !hasEnumLabelLines(c.Comment) {
+			return strmangle.TitleCase(enumName) + "Slice"
+		}
+		return "types.StringArray"
+	}
+
+	switch *c.ArrType {
+	case "int2", "int4", "int8", "int", "integer", "serial", "smallint", "smallserial", "bigint", "bigserial":
+		return "types.Int64Array"
+	case "bytes", "bytea":
+		return "types.BytesArray"
+	case "string", "collate", "bit", "bit varying", "character", "character varying", "char", "varchar", "text":
+		return "types.StringArray"
+	case "interval":
+		return "crdbtypes.IntervalArray"
+	case "inet":
+		if d.inetNetip {
+			return "crdbtypes.InetArray"
+		}
+		return "types.StringArray"
+	case "uuid":
+		if d.uuidPackage != "" {
+			return "CRDBUUIDArray"
+		}
+		return "types.StringArray"
+	case "date":
+		if d.civilTime {
+			return "crdbtypes.DateArray"
+		}
+		return "types.StringArray"
+	case "time", "time without time zone":
+		if d.civilTime {
+			return "crdbtypes.TimeOfDayArray"
+		}
+		return "types.StringArray"
+	case "timetz", "time with time zone":
+		if d.civilTime {
+			return "crdbtypes.TimeOfDayTZArray"
+		}
+		return "types.StringArray"
+	case "bool", "boolean":
+		return "types.BoolArray"
+	case "decimal", "numeric":
+		return "types.DecimalArray"
+	case "float8", "float", "double precision", "real":
+		return "types.Float64Array"
+	default:
+		fmt.Fprintf(os.Stderr, "Warning: Unhandled array data type %s, falling back to types.StringArray\n", *c.ArrType)
+		return "types.StringArray"
+	}
+}
@@ -0,0 +1,97 @@
+package driver
+
+import (
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestTypeModifiers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dbType   string
+		dataType string
+		want     string
+	}{
+		{"varchar", "VARCHAR(1000)", "varchar(1000)"},
+		{"decimal", "DECIMAL(2,1)", "decimal(2,1)"},
+		{"decimal", "DECIMAL(5)", "decimal(5,0)"},
+		{"timestamptz", "TIMESTAMPTZ(3)", "timestamptz(3)"},
+		{"bit", "BIT(8)", "bit(8)"},
+		{"geography", "GEOGRAPHY(POINT,4326)", "geography(point,4326)"},
+		{"int8", "INT8", ""},
+		{"varchar[]", "VARCHAR(10)[]", ""},
+	}
+
+	for _, test := range tests {
+		if got := typeModifiers(test.dbType, test.dataType); got != test.want {
+			t.Errorf("%s: want %q, got %q", test.dataType, test.want, got)
+		}
+	}
+}
+
+func TestTranslateColumnType(t *testing.T) {
+	t.Parallel()
+
+	arrType := func(s string) *string { return &s }
+
+	tests := []struct {
+		driver *CockroachDBDriver
+		col    drivers.Column
+		want   string
+	}{
+		{&CockroachDBDriver{}, drivers.Column{DBType: "interval"}, "crdbtypes.Interval"},
+		{&CockroachDBDriver{}, drivers.Column{DBType: "interval", Nullable: true}, "crdbtypes.NullInterval"},
+		{&CockroachDBDriver{}, drivers.Column{DBType: "array", ArrType: arrType("interval")}, "crdbtypes.IntervalArray"},
+		{&CockroachDBDriver{}, drivers.Column{DBType: "inet"}, "string"},
+		{&CockroachDBDriver{}, drivers.Column{DBType: "inet", Nullable: true}, "null.String"},
+		{&CockroachDBDriver{}, drivers.Column{DBType: "array", ArrType: arrType("inet")}, "types.StringArray"},
+		{&CockroachDBDriver{inetNetip: true}, drivers.Column{DBType: "inet"}, "crdbtypes.Inet"},
+		{&CockroachDBDriver{inetNetip: true}, drivers.Column{DBType: "inet", Nullable: true}, "crdbtypes.NullInet"},
+		{&CockroachDBDriver{inetNetip: true}, drivers.Column{DBType: "array", ArrType: arrType("inet")}, "crdbtypes.InetArray"},
+		{&CockroachDBDriver{}, drivers.Column{DBType: "uuid"}, "string"},
+		{&CockroachDBDriver{}, drivers.Column{DBType: "uuid", Nullable: true}, "null.String"},
+		{&CockroachDBDriver{}, drivers.Column{DBType: "array", ArrType: arrType("uuid")}, "types.StringArray"},
+		{&CockroachDBDriver{uuidPackage: "github.com/gofrs/uuid"}, drivers.Column{DBType: "uuid"}, "CRDBUUID"},
+		{&CockroachDBDriver{uuidPackage: "github.com/gofrs/uuid"}, drivers.Column{DBType: "uuid", Nullable: true}, "CRDBNullUUID"},
+		{&CockroachDBDriver{uuidPackage: "github.com/gofrs/uuid"}, drivers.Column{DBType: "array", ArrType: arrType("uuid")}, "CRDBUUIDArray"},
+		{&CockroachDBDriver{}, drivers.Column{DBType: "date"}, "time.Time"},
+		{&CockroachDBDriver{}, drivers.Column{DBType: "time", Nullable: true}, "null.Time"},
+		{&CockroachDBDriver{}, drivers.Column{DBType: "timetz"}, "string"},
+		{&CockroachDBDriver{}, drivers.Column{DBType: "time with time zone", Nullable: true}, "null.String"},
+		{&CockroachDBDriver{}, drivers.Column{DBType: "array", ArrType: arrType("date")}, "types.StringArray"},
+		{&CockroachDBDriver{civilTime: true}, drivers.Column{DBType: "date"}, "crdbtypes.Date"},
+		{&CockroachDBDriver{civilTime: true}, drivers.Column{DBType: "date", Nullable: true}, "crdbtypes.NullDate"},
+		{&CockroachDBDriver{civilTime: true}, drivers.Column{DBType: "array", ArrType: arrType("date")}, "crdbtypes.DateArray"},
+		{&CockroachDBDriver{civilTime: true}, drivers.Column{DBType: "time"}, "crdbtypes.TimeOfDay"},
+		{&CockroachDBDriver{civilTime: true}, drivers.Column{DBType: "time", Nullable: true}, "crdbtypes.NullTimeOfDay"},
+		{&CockroachDBDriver{civilTime: true}, drivers.Column{DBType: "array", ArrType: arrType("time")}, "crdbtypes.TimeOfDayArray"},
+		{&CockroachDBDriver{civilTime: true}, drivers.Column{DBType: "timetz"}, "crdbtypes.TimeOfDayTZ"},
+		{&CockroachDBDriver{civilTime: true}, drivers.Column{DBType: "timetz", Nullable: true}, "crdbtypes.NullTimeOfDayTZ"},
+		{&CockroachDBDriver{civilTime: true}, drivers.Column{DBType: "array", ArrType: arrType("timetz")}, "crdbtypes.TimeOfDayTZArray"},
+		{&CockroachDBDriver{civilTime: true}, drivers.Column{DBType: "timestamptz"}, "time.Time"},
+		{&CockroachDBDriver{}, drivers.Column{DBType: "enum.crdb_internal_region('europe-west1','us-east1')"}, "CRDBInternalRegion"},
+		{&CockroachDBDriver{enumNullPrefix: "Null"}, drivers.Column{DBType: "enum.crdb_internal_region('europe-west1','us-east1')", Nullable: true}, "NullCRDBInternalRegion"},
+		{&CockroachDBDriver{addEnumTypes: true}, drivers.Column{DBType: "enum.workday('monday','tuesday')"}, "Workday"},
+		{&CockroachDBDriver{addEnumTypes: true}, drivers.Column{DBType: "enum.workday('monday')", Comment: "enum: workday\nenum-label: \"monday\"\nenum-label: \"it's\""}, "string"},
+		{&CockroachDBDriver{addEnumTypes: true}, drivers.Column{DBType: "enum.workday('monday')", Comment: "enum: workday\nenum-label: \"monday\"\nenum-label: \"it's\"", Nullable: true}, "null.String"},
+		{&CockroachDBDriver{addEnumTypes: true}, drivers.Column{DBType: "array", ArrType: arrType("enum.workday('monday')"), Comment: "enum: workday\nenum-label: \"monday\"\nenum-label: \"it's\""}, "types.StringArray"},
+		{&CockroachDBDriver{}, drivers.Column{DBType: "geometry"}, "crdbtypes.Geometry"},
+		{&CockroachDBDriver{}, drivers.Column{DBType: "geography", FullDBType: "geography(point,4326)", Nullable: true}, "crdbtypes.NullGeometry"},
+	}
+
+	for i, test := range tests {
+		got := test.driver.TranslateColumnType(test.col)
+		if got.Type != test.want {
+			t.Errorf("%d: want %s, got %s", i, test.want, got.Type)
+		}
+		// Randomize gets the shape and SRID of spatial columns from the DBType
+		if test.col.FullDBType != "" && got.DBType != test.col.FullDBType {
+			t.Errorf("%d: want DBType %s, got %s", i, test.col.FullDBType, got.DBType)
+		}
+		if pkg := test.driver.uuidPackage; pkg != "" && got.Comment != "go:uuid="+pkg {
+			t.Errorf("%d: want a go:uuid=%s comment, got %q", i, pkg, got.Comment)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package driver
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+var rgxVersion = regexp.MustCompile(`v(\d+)\.(\d+)\.(\d+)`)
+
+type (
+	// version is a CockroachDB release, as in v21.2.3
+	version struct {
+		major int
+		minor int
+		patch int
+	}
+	// capabilities describes what the connected CockroachDB version supports,
+	// introspection picks its queries from it rather than from error messages.
+	capabilities struct {
+		version version
+
+		// crdbSQLType is information_schema.columns.crdb_sql_type
+		crdbSQLType bool
+		// hiddenColumns is information_schema.columns.is_hidden
+		hiddenColumns bool
+		// computedColumns is information_schema.columns.generation_expression
+		computedColumns bool
+		// sequences is CREATE SEQUENCE and information_schema.sequences
+		sequences bool
+		// indexes is information_schema.statistics.implicit and pg_indexes
+		indexes bool
+		// checks is pg_constraint with the columns of check constraints
+		checks bool
+		// comments is COMMENT ON and pg_description
+		comments bool
+		// enums is user-defined enum types
+		enums bool
+		// identityColumns is GENERATED ... AS IDENTITY
+		identityColumns bool
+		// multiRegion is table localities, REGIONAL BY ROW included
+		multiRegion bool
+		// rowLevelTTL is the ttl_expire_after storage parameter
+		rowLevelTTL bool
+		// ttlExpirationExpression is the ttl_expiration_expression storage parameter
+		ttlExpirationExpression bool
+	}
+)
+
+func (v version) atLeast(major, minor, patch int) bool {
+	if v.major != major {
+		return v.major > major
+	}
+	if v.minor != minor {
+		return v.minor > minor
+	}
+	return v.patch >= patch
+}
+
+func (v version) String() string {
+	return fmt.Sprintf("v%d.%d.%d", v.major, v.minor, v.patch)
+}
+
+// parseVersion finds the release in the output of version() or in the
+// build tag of crdb_internal.node_build_info.
+func parseVersion(s string) (version, error) {
+	m := rgxVersion.FindStringSubmatch(s)
+	if m == nil {
+		return version{}, errors.Errorf("unable to find a CockroachDB version in %q", s)
+	}
+
+	var v version
+	v.major, _ = strconv.Atoi(m[1])
+	v.minor, _ = strconv.Atoi(m[2])
+	v.patch, _ = strconv.Atoi(m[3])
+
+	return v, nil
+}
+
+func newCapabilities(v version) capabilities {
+	return capabilities{
+		version: v,
+
+		crdbSQLType:             v.atLeast(2, 1, 0),
+		hiddenColumns:           v.atLeast(2, 0, 0),
+		computedColumns:         v.atLeast(2, 0, 0),
+		sequences:               v.atLeast(2, 0, 0),
+		indexes:                 v.atLeast(2, 0, 0),
+		checks:                  v.atLeast(2, 0, 0),
+		comments:                v.atLeast(19, 1, 0),
+		enums:                   v.atLeast(20, 2, 0),
+		identityColumns:         v.atLeast(21, 2, 0),
+		multiRegion:             v.atLeast(21, 1, 0),
+		rowLevelTTL:             v.atLeast(22, 1, 0),
+		ttlExpirationExpression: v.atLeast(22, 2, 0),
+	}
+}
+
+// detectCapabilities asks the cluster which version it runs, falling back to
+// the build info when version() doesn't carry a release tag.
+func (d *CockroachDBDriver) detectCapabilities() (capabilities, error) {
+	var s string
+	if err := d.conn.QueryRow(`SELECT version();`).Scan(&s); err != nil {
+		return capabilities{}, err
+	}
+
+	v, err := parseVersion(s)
+	if err != nil {
+		var tag string
+		row := d.conn.QueryRow(`SELECT value FROM crdb_internal.node_build_info WHERE field = 'Tag';`)
+		if e := row.Scan(&tag); e != nil {
+			if e == sql.ErrNoRows {
+				return capabilities{}, err
+			}
+			return capabilities{}, e
+		}
+		if v, err = parseVersion(tag); err != nil {
+			return capabilities{}, err
+		}
+	}
+
+	return newCapabilities(v), nil
+}
@@ -0,0 +1,56 @@
+package driver
+
+import (
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want version
+	}{
+		{"CockroachDB CCL v21.2.3 (x86_64-unknown-linux-gnu, built 2021/12/14 15:22:02, go1.16.6)", version{21, 2, 3}},
+		{"CockroachDB OSS v2.0.7 (x86_64-unknown-linux-gnu, built 2018/11/12 21:42:42, go1.10)", version{2, 0, 7}},
+		{"v23.1.0-alpha.8-1131-g12ab34cd", version{23, 1, 0}},
+	}
+
+	for _, test := range tests {
+		got, err := parseVersion(test.in)
+		if err != nil {
+			t.Errorf("%s: %v", test.in, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%s: want %s, got %s", test.in, test.want, got)
+		}
+	}
+
+	if _, err := parseVersion("PostgreSQL 13.3"); err == nil {
+		t.Error("want an error for a version without a CockroachDB release")
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	t.Parallel()
+
+	if caps := newCapabilities(version{1, 1, 0}); caps.sequences || caps.indexes || caps.checks || caps.comments {
+		t.Errorf("v1.1.0: unexpected capabilities %+v", caps)
+	}
+	if caps := newCapabilities(version{2, 1, 0}); !caps.sequences || !caps.indexes || !caps.checks || caps.comments {
+		t.Errorf("v2.1.0: unexpected capabilities %+v", caps)
+	}
+	if caps := newCapabilities(version{20, 1, 0}); caps.enums || !caps.crdbSQLType || !caps.hiddenColumns || !caps.comments {
+		t.Errorf("v20.1.0: unexpected capabilities %+v", caps)
+	}
+	if caps := newCapabilities(version{20, 2, 0}); !caps.enums || caps.multiRegion {
+		t.Errorf("v20.2.0: unexpected capabilities %+v", caps)
+	}
+	if caps := newCapabilities(version{22, 1, 0}); !caps.rowLevelTTL || caps.ttlExpirationExpression {
+		t.Errorf("v22.1.0: unexpected capabilities %+v", caps)
+	}
+	if caps := newCapabilities(version{22, 2, 0}); !caps.identityColumns || !caps.multiRegion || !caps.ttlExpirationExpression {
+		t.Errorf("v22.2.0: unexpected capabilities %+v", caps)
+	}
+}
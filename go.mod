@@ -1,6 +1,6 @@
 module github.com/dgollings/sqlboiler-crdb/v4
 
-go 1.16
+go 1.18
 
 require (
 	github.com/lib/pq v1.8.0
@@ -9,3 +9,12 @@ require (
 	github.com/volatiletech/sqlboiler/v4 v4.8.6
 	github.com/volatiletech/strmangle v0.0.1
 )
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/friendsofgo/errors v0.9.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/spf13/cast v1.4.1 // indirect
+	github.com/volatiletech/inflect v0.0.1 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b // indirect
+)